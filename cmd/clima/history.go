@@ -0,0 +1,195 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/esferadigital/clima/internal/location"
+	"github.com/esferadigital/clima/internal/openmeteo"
+)
+
+var historyDaily = []openmeteo.DailyWeatherVariables{
+	openmeteo.DailyWeatherCode,
+	openmeteo.Temperature2mMin,
+	openmeteo.Temperature2mMax,
+	openmeteo.PrecipitationSum,
+	openmeteo.WindSpeed10mMax,
+}
+
+var historyHourly = []openmeteo.HourlyWeatherVariables{
+	openmeteo.HourlyWeatherCode,
+	openmeteo.HourlyTemperature2m,
+	openmeteo.HourlyRelativeHumidity2m,
+	openmeteo.HourlyPrecipitation,
+	openmeteo.HourlyWindSpeed10m,
+}
+
+// clima history <location> [--from YYYY-MM-DD] [--to YYYY-MM-DD] [--hourly] [--format table|csv|json]
+func runHistory(args []string) {
+	fs := flag.NewFlagSet("history", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: clima history <location> [flags]")
+		fs.PrintDefaults()
+	}
+	from := fs.String("from", "", "First day as YYYY-MM-DD (default a week before --to)")
+	to := fs.String("to", "", "Last day as YYYY-MM-DD (default yesterday)")
+	hourly := fs.Bool("hourly", false, "Print hourly values instead of daily ones")
+	format := fs.String("format", "table", "Output format: table, csv or json")
+	query := parseWithPositional(fs, args)
+	if query == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	end := time.Now().AddDate(0, 0, -1)
+	if *to != "" {
+		end = mustParseDate("--to", *to)
+	}
+	start := end.AddDate(0, 0, -6)
+	if *from != "" {
+		start = mustParseDate("--from", *from)
+	}
+	if start.After(end) {
+		fmt.Fprintln(os.Stderr, "--from must not be after --to")
+		os.Exit(2)
+	}
+
+	cfg := mustLoadConfig()
+	loc, err := location.Resolve(query)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to find location: %v\n", err)
+		os.Exit(1)
+	}
+
+	params := openmeteo.ArchiveParams{
+		Latitude:  loc.Latitude,
+		Longitude: loc.Longitude,
+		StartDate: start,
+		EndDate:   end,
+		Units:     cfg.Units,
+	}
+	if *hourly {
+		params.Hourly = historyHourly
+	} else {
+		params.Daily = historyDaily
+	}
+	res, err := openmeteo.GetArchive(params)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to get history: %v\n", err)
+		os.Exit(1)
+	}
+
+	series, units, names := res.Daily, res.DailyUnits, variableNames(historyDaily)
+	if *hourly {
+		series, units, names = res.Hourly, res.HourlyUnits, variableNames(historyHourly)
+	}
+
+	switch *format {
+	case "table":
+		fmt.Printf("%s\n\n", loc.Label())
+		writeHistoryTable(os.Stdout, series, units, names)
+	case "csv":
+		err = writeHistoryCSV(os.Stdout, series, units, names)
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(res)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown format %q\n", *format)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write history: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func writeHistoryTable(w io.Writer, series map[string]any, units map[string]any, names []string) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	defer tw.Flush()
+
+	header := []string{"time"}
+	for _, name := range names {
+		header = append(header, columnName(name, units))
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+	for i, t := range openmeteo.SeriesTimes(series) {
+		row := []string{t}
+		for _, name := range names {
+			v, ok := openmeteo.SeriesValue(series, name, i)
+			switch {
+			case !ok:
+				row = append(row, "-")
+			case name == string(openmeteo.DailyWeatherCode):
+				row = append(row, openmeteo.MapWeatherCode(v))
+			default:
+				row = append(row, fmt.Sprintf("%.1f", v))
+			}
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+}
+
+func writeHistoryCSV(w io.Writer, series map[string]any, units map[string]any, names []string) error {
+	cw := csv.NewWriter(w)
+
+	header := []string{"time"}
+	for _, name := range names {
+		header = append(header, columnName(name, units))
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for i, t := range openmeteo.SeriesTimes(series) {
+		row := []string{t}
+		for _, name := range names {
+			v, ok := openmeteo.SeriesValue(series, name, i)
+			if !ok {
+				row = append(row, "")
+				continue
+			}
+			row = append(row, strconv.FormatFloat(v, 'f', -1, 64))
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func columnName(name string, units map[string]any) string {
+	unit, _ := units[name].(string)
+	if unit == "" || name == "weather_code" {
+		return name
+	}
+	return fmt.Sprintf("%s (%s)", name, unit)
+}
+
+func variableNames[T ~string](variables []T) []string {
+	names := make([]string, len(variables))
+	for i, v := range variables {
+		names[i] = string(v)
+	}
+	return names
+}
+
+func mustParseDate(flagName string, value string) time.Time {
+	date, err := time.ParseInLocation(time.DateOnly, value, time.Local)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid %s date %q, expected YYYY-MM-DD\n", flagName, value)
+		os.Exit(2)
+	}
+	return date
+}
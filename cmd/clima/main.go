@@ -5,20 +5,52 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/esferadigital/clima/internal/alert"
+	"github.com/esferadigital/clima/internal/config"
+	"github.com/esferadigital/clima/internal/hook"
+	"github.com/esferadigital/clima/internal/i18n"
+	"github.com/esferadigital/clima/internal/location"
+	"github.com/esferadigital/clima/internal/openmeteo"
+	"github.com/esferadigital/clima/internal/provider"
 	"github.com/esferadigital/clima/internal/tui"
+	"github.com/esferadigital/clima/internal/tui/compare"
+	"github.com/esferadigital/clima/internal/tui/icons"
+	"github.com/esferadigital/clima/internal/tui/keybind"
+	"github.com/esferadigital/clima/internal/tui/theme"
 )
 
 const DEBUG_PATH = "dev/debug.log"
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "history":
+			runHistory(os.Args[2:])
+			return
+		case "now":
+			runNow(os.Args[2:])
+			return
+		case "watch":
+			runWatch(os.Args[2:])
+			return
+		case "serve":
+			runServe(os.Args[2:])
+			return
+		}
+	}
+
 	var (
 		sink *os.File
 		err  error
 	)
 
 	debug := flag.Bool("debug", false, "Save logs to file")
+	startAt := flag.String("location", "", "Open the forecast of a location: a name, an alias or \"lat,lon\". Overrides home in the config")
+	ip := flag.String("ip", "", "Public IP to locate in the IP database for the current location")
 	flag.Parse()
 
 	if *debug {
@@ -33,9 +65,127 @@ func main() {
 		defer sink.Close()
 	}
 
-	if _, err = tea.NewProgram(tui.InitialModel(sink), tea.WithAltScreen()).Run(); err != nil {
+	cfg := mustLoadConfig()
+	mustLoadSources(&cfg, *ip)
+	// Only the TUI is styled, so the terminal is not queried for other commands
+	t, err := theme.Load(cfg.Theme, cfg.ThemeColors)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid theme config: %v\n", err)
+		os.Exit(1)
+	}
+	theme.Set(t)
+	keys, err := keybind.Load(cfg.Keys.Preset, cfg.Keys.Bindings)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid keys config: %v\n", err)
+		os.Exit(1)
+	}
+	keybind.Set(keys)
+
+	start, err := startLocation(*startAt, cfg.Home)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to find location: %v\n", err)
+		os.Exit(1)
+	}
+
+	// The terminal belongs to the TUI, hooks only report to the debug log
+	if sink != nil {
+		hook.SetLog(sink)
+	}
+
+	_, err = tea.NewProgram(tui.InitialModel(sink, cfg, start), tea.WithAltScreen(), tea.WithReportFocus()).Run()
+	waitForHooks()
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "TUI program run failed: %v\n", err)
 		os.Exit(1)
 	}
 }
 
+func mustLoadConfig() config.Config {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+	if _, err = provider.FromConfig(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid provider config: %v\n", err)
+		os.Exit(1)
+	}
+	if err = compare.Validate(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid compare_models config: %v\n", err)
+		os.Exit(1)
+	}
+	if _, err = icons.ParseStyle(cfg.Icons); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid icons config: %v\n", err)
+		os.Exit(1)
+	}
+	if _, err = alert.FromConfig(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid alerts config: %v\n", err)
+		os.Exit(1)
+	}
+	if err = hook.Validate(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid hooks config: %v\n", err)
+		os.Exit(1)
+	}
+	i18n.Set(i18n.Detect(cfg.Locale))
+	return cfg
+}
+
+// Time hooks still running at exit get to finish.
+const HOOK_WAIT_TIMEOUT = 15 * time.Second
+
+// Let hooks still running finish before exiting, saying so since it can take a while.
+func waitForHooks() {
+	n := hook.Pending()
+	if n == 0 {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Waiting up to %s for %d hook deliveries to finish...\n", HOOK_WAIT_TIMEOUT, n)
+	if !hook.Wait(HOOK_WAIT_TIMEOUT) {
+		fmt.Fprintf(os.Stderr, "Gave up on %d hook deliveries\n", hook.Pending())
+	}
+}
+
+// Sources of the current position. The IP from a flag replaces the one in the config.
+func mustLoadSources(cfg *config.Config, ip string) location.Sources {
+	if ip != "" {
+		cfg.Position.IP = ip
+	}
+	sources, err := location.SourcesFromConfig(*cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid position config: %v\n", err)
+		os.Exit(1)
+	}
+	return sources
+}
+
+// Location to open at startup, from the flag or else the home in the config.
+// Nil when there is neither, so the TUI starts at the recent list.
+func startLocation(flagged string, home string) (*openmeteo.GeocodingResult, error) {
+	query := flagged
+	if query == "" {
+		query = home
+	}
+	if query == "" {
+		return nil, nil
+	}
+	loc, err := location.Resolve(query)
+	if err != nil {
+		return nil, err
+	}
+	return &loc, nil
+}
+
+// Parse flags placed before or after the positional arguments,
+// which are joined with spaces so `clima history New York` works unquoted.
+func parseWithPositional(fs *flag.FlagSet, args []string) string {
+	var positional []string
+	for {
+		fs.Parse(args)
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	return strings.Join(positional, " ")
+}
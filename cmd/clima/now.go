@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/esferadigital/clima/internal/derived"
+	"github.com/esferadigital/clima/internal/forecast"
+	"github.com/esferadigital/clima/internal/i18n"
+	"github.com/esferadigital/clima/internal/location"
+	"github.com/esferadigital/clima/internal/openmeteo"
+)
+
+// clima now <location> [--format text|json]
+// clima now --here [--ip <address>] [--format text|json]
+func runNow(args []string) {
+	fs := flag.NewFlagSet("now", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: clima now <location> [flags]")
+		fmt.Fprintln(fs.Output(), "       clima now --here [flags]")
+		fs.PrintDefaults()
+	}
+	format := fs.String("format", "text", "Output format: text or json")
+	here := fs.Bool("here", false, "Use the current position from the position sources in the config")
+	ip := fs.String("ip", "", "Public IP to locate in the IP database, with --here")
+	query := parseWithPositional(fs, args)
+	// Either a location or --here
+	if (query == "") != *here {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	cfg := mustLoadConfig()
+	var (
+		loc openmeteo.GeocodingResult
+		err error
+	)
+	if *here {
+		loc, err = mustLoadSources(&cfg, *ip).Locate()
+	} else {
+		loc, err = location.Resolve(query)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to find location: %v\n", err)
+		os.Exit(1)
+	}
+
+	cached, err := forecast.Get(cfg, loc)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to get forecast: %v\n", err)
+		os.Exit(1)
+	}
+	res := cached.Forecast
+
+	switch *format {
+	case "text":
+		writeNowText(os.Stdout, loc, res)
+	case "json":
+		err = writeNowJSON(os.Stdout, loc, res)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown format %q\n", *format)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write conditions: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func writeNowText(w io.Writer, loc openmeteo.GeocodingResult, res openmeteo.ForecastResponse) {
+	current, units := res.Current, res.CurrentUnits
+	value := func(v openmeteo.CurrentWeatherVariables) string {
+		n, ok := current[string(v)].(float64)
+		if !ok {
+			return "-"
+		}
+		return i18n.Sprintf("%.1f %s", n, units[string(v)])
+	}
+	row := func(tw io.Writer, name string, value string) {
+		fmt.Fprintf(tw, "%s\t%s\n", i18n.T(name), value)
+	}
+
+	fmt.Fprintf(w, "%s\n", loc.Label())
+	if code, ok := current[string(openmeteo.WeatherCode)].(float64); ok {
+		fmt.Fprintln(w, i18n.T(openmeteo.MapWeatherCode(code)))
+	}
+	fmt.Fprintf(w, "%s %s\n\n", value(openmeteo.Temperature2m), i18n.T("(feels like %s)", value(openmeteo.ApparentTemperature)))
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	defer tw.Flush()
+
+	conditions, ok := derived.FromForecast(res)
+	if ok {
+		force := conditions.Beaufort
+		row(tw, "Wind", value(openmeteo.WindSpeed10m)+" "+i18n.T(conditions.Compass)+" "+i18n.T("(force %d, %s)", force.Number, strings.ToLower(i18n.T(force.Description))))
+	} else {
+		row(tw, "Wind", value(openmeteo.WindSpeed10m))
+	}
+	row(tw, "Wind gusts", value(openmeteo.WindGusts10m))
+	row(tw, "Humidity", value(openmeteo.RelativeHumidity2m))
+	if ok {
+		unit := units[string(openmeteo.Temperature2m)]
+		row(tw, "Dew point", i18n.Sprintf("%.1f %s", conditions.DewPoint, unit))
+		if conditions.HasHeatIndex {
+			row(tw, "Heat index", i18n.Sprintf("%.1f %s", conditions.HeatIndex, unit))
+		}
+		if conditions.HasHumidex {
+			row(tw, "Humidex", i18n.Sprintf("%.1f %s", conditions.Humidex, unit))
+		}
+		if conditions.HasWindChill {
+			row(tw, "Wind chill", i18n.Sprintf("%.1f %s", conditions.WindChill, unit))
+		}
+		row(tw, "Comfort", i18n.T(conditions.Comfort))
+	}
+	row(tw, "Precipitation", value(openmeteo.Precipitation))
+	row(tw, "Pressure", value(openmeteo.SeaLevelPressure))
+}
+
+// Output of `clima now --format json`.
+// Kept in English, like the API, so scripts do not depend on the locale.
+type nowOutput struct {
+	Location     openmeteo.GeocodingResult `json:"location"`
+	Time         any                       `json:"time"`
+	Conditions   string                    `json:"conditions"`
+	Current      map[string]any            `json:"current"`
+	CurrentUnits map[string]any            `json:"current_units"`
+	Derived      *nowDerived               `json:"derived,omitempty"`
+}
+
+type nowDerived struct {
+	Compass      string   `json:"wind_compass"`
+	Beaufort     int      `json:"beaufort"`
+	BeaufortName string   `json:"beaufort_description"`
+	DewPoint     float64  `json:"dew_point"`
+	HeatIndex    *float64 `json:"heat_index,omitempty"`
+	Humidex      *float64 `json:"humidex,omitempty"`
+	WindChill    *float64 `json:"wind_chill,omitempty"`
+	Comfort      string   `json:"comfort"`
+}
+
+func writeNowJSON(w io.Writer, loc openmeteo.GeocodingResult, res openmeteo.ForecastResponse) error {
+	out := nowOutput{
+		Location:     loc,
+		Time:         res.Current["time"],
+		Current:      res.Current,
+		CurrentUnits: res.CurrentUnits,
+	}
+	if code, ok := res.Current[string(openmeteo.WeatherCode)].(float64); ok {
+		out.Conditions = openmeteo.MapWeatherCode(code)
+	}
+	if c, ok := derived.FromForecast(res); ok {
+		out.Derived = &nowDerived{
+			Compass:      c.Compass,
+			Beaufort:     c.Beaufort.Number,
+			BeaufortName: c.Beaufort.Description,
+			DewPoint:     c.DewPoint,
+			Comfort:      c.Comfort,
+		}
+		if c.HasHeatIndex {
+			out.Derived.HeatIndex = &c.HeatIndex
+		}
+		if c.HasHumidex {
+			out.Derived.Humidex = &c.Humidex
+		}
+		if c.HasWindChill {
+			out.Derived.WindChill = &c.WindChill
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
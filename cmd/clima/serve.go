@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/esferadigital/clima/internal/config"
+	"github.com/esferadigital/clima/internal/hook"
+	"github.com/esferadigital/clima/internal/server"
+)
+
+// Time given to requests under way to finish when stopping.
+const SHUTDOWN_TIMEOUT = 10 * time.Second
+
+// clima serve [--addr host:port] [--every <duration>]
+func runServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: clima serve [flags]")
+		fmt.Fprintln(fs.Output(), "Serves recent locations and forecasts as JSON under /v1, and metrics under /metrics.")
+		fs.PrintDefaults()
+	}
+	addr := fs.String("addr", "127.0.0.1:8079", "Address to listen on")
+	every := fs.Duration("every", 0, "Time between updates of the exported locations (default refresh_minutes of the config)")
+	fs.Parse(args)
+
+	cfg := mustLoadConfig()
+	if err := serve(cfg, *addr, checkInterval(*every, cfg)); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to serve: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// Serve until interrupted, then let requests and hooks under way finish.
+// Returns why the server could not listen, if it could not.
+func serve(cfg config.Config, addr string, every time.Duration) error {
+	hook.SetLog(os.Stderr)
+	defer waitForHooks()
+
+	srv := &http.Server{
+		Handler:           server.New(cfg),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if locations := server.ExportedLocations(cfg); len(locations) > 0 {
+		go server.Export(ctx, cfg, locations, every, os.Stderr)
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Listening on http://%s\n", listener.Addr())
+
+	failed := make(chan error, 1)
+	go func() {
+		if err := srv.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+			failed <- err
+		}
+	}()
+
+	select {
+	case err := <-failed:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), SHUTDOWN_TIMEOUT)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to stop: %v\n", err)
+	}
+	return nil
+}
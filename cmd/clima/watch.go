@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/esferadigital/clima/internal/alert"
+	"github.com/esferadigital/clima/internal/config"
+	"github.com/esferadigital/clima/internal/forecast"
+	"github.com/esferadigital/clima/internal/hook"
+	"github.com/esferadigital/clima/internal/location"
+	"github.com/esferadigital/clima/internal/notify"
+	"github.com/esferadigital/clima/internal/store"
+)
+
+// Check interval when background refreshes are disabled in the config.
+const DEFAULT_WATCH_INTERVAL = 15 * time.Minute
+
+// clima watch [--every <duration>] [--once] [--dry-run]
+func runWatch(args []string) {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: clima watch [flags]")
+		fmt.Fprintln(fs.Output(), "Checks the alert rules of the config and notifies when one starts to hold.")
+		fs.PrintDefaults()
+	}
+	every := fs.Duration("every", 0, "Time between checks (default refresh_minutes of the config)")
+	once := fs.Bool("once", false, "Check once, notify and exit, e.g. from cron")
+	dryRun := fs.Bool("dry-run", false, "Print alerts and hook events instead of sending them")
+	fs.Parse(args)
+
+	cfg := mustLoadConfig()
+	hook.SetLog(os.Stderr)
+	defer waitForHooks()
+	if *dryRun {
+		for i := range cfg.Hooks {
+			cfg.Hooks[i].DryRun = true
+		}
+	}
+	rules, _ := alert.FromConfig(cfg)
+	watched := watchedRules(rules, cfg.Home)
+	if len(watched) == 0 {
+		fmt.Fprintln(os.Stderr, "No alert rules with a location to watch: set one per rule or a home in the config")
+		os.Exit(1)
+	}
+
+	interval := checkInterval(*every, cfg)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	notifier := notify.FromConfig(cfg)
+	if *dryRun {
+		notifier = notify.DryRun{}
+	}
+	// Alerts notified and still holding, so each is only sent when it starts.
+	// Kept on disk so runs from cron do not send them again either.
+	active, err := store.LoadActiveAlerts()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load notified alerts: %v\n", err)
+		active = map[string]bool{}
+	}
+	for {
+		active = checkRules(cfg, watched, notifier, active)
+		// A dry run sends nothing, so nothing it finds counts as notified
+		if !*dryRun {
+			if err := store.SaveActiveAlerts(active); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to save notified alerts: %v\n", err)
+			}
+		}
+		if *once {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// The interval of the flag if set, else refresh_minutes of the config, else the default.
+func checkInterval(every time.Duration, cfg config.Config) time.Duration {
+	if every > 0 {
+		return every
+	}
+	if cfg.RefreshMinutes > 0 {
+		return time.Duration(cfg.RefreshMinutes) * time.Minute
+	}
+	return DEFAULT_WATCH_INTERVAL
+}
+
+// Rules by the location they watch. Rules without one watch the home location.
+func watchedRules(rules []alert.Rule, home string) map[string][]alert.Rule {
+	watched := map[string][]alert.Rule{}
+	for _, rule := range rules {
+		query := rule.Location
+		if query == "" {
+			query = home
+		}
+		if query == "" {
+			fmt.Fprintf(os.Stderr, "Skipping %s: no location and no home\n", rule.Name)
+			continue
+		}
+		watched[query] = append(watched[query], rule)
+	}
+	return watched
+}
+
+// Fetch the forecast of each watched location and notify alerts that started holding.
+// Returns the alerts holding now. Failures are reported and retried on the next check.
+func checkRules(cfg config.Config, watched map[string][]alert.Rule, notifier notify.Notifier, active map[string]bool) map[string]bool {
+	holding := map[string]bool{}
+	now := time.Now()
+	for query, rules := range watched {
+		loc, err := location.Resolve(query)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to find location %q: %v\n", query, err)
+			keepActive(holding, active, query, rules)
+			continue
+		}
+		res, _, err := forecast.Fetch(cfg, loc)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to get forecast for %s: %v\n", loc.Label(), err)
+			keepActive(holding, active, query, rules)
+			continue
+		}
+
+		for _, rule := range rules {
+			a, ok := rule.Check(loc, res, now)
+			if !ok {
+				continue
+			}
+			key := rule.Name + "@" + query
+			holding[key] = true
+			if active[key] {
+				continue
+			}
+			fmt.Printf("%s %s at %s: %s\n", now.Format(time.DateTime), rule.Name, loc.Label(), a.Details())
+			if err := notifier.Notify(a); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to notify %s: %v\n", rule.Name, err)
+				// Not sent, so it is tried again next time
+				delete(holding, key)
+			}
+		}
+	}
+	return holding
+}
+
+// Keep the state of rules that could not be checked, so a failed fetch does not notify them again.
+func keepActive(holding map[string]bool, active map[string]bool, query string, rules []alert.Rule) {
+	for _, rule := range rules {
+		key := rule.Name + "@" + query
+		if active[key] {
+			holding[key] = true
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package alert
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/esferadigital/clima/internal/config"
+	"github.com/esferadigital/clima/internal/location"
+	"github.com/esferadigital/clima/internal/openmeteo"
+)
+
+// Hours checked by a condition without `within`: the one under way.
+const DEFAULT_WINDOW = time.Hour
+
+// Comparison of an hourly forecast variable with a threshold, in the units
+// of the config, during the next hours.
+type Condition struct {
+	Variable  openmeteo.HourlyWeatherVariables
+	Operator  string
+	Threshold float64
+	Window    time.Duration
+}
+
+// Conditions in groups: the expression holds when every condition
+// of any group holds. `and` joins conditions, `or` joins groups.
+type Expression [][]Condition
+
+// Alert rule from the config.
+type Rule struct {
+	Name     string
+	Location string
+	When     Expression
+}
+
+// Hour at which a condition held, with the value that made it hold.
+type Match struct {
+	Condition
+	Value float64
+	Unit  string
+	At    time.Time
+}
+
+// Rule that holds for a location.
+type Alert struct {
+	Rule     Rule
+	Location openmeteo.GeocodingResult
+	Matches  []Match
+}
+
+// Rules from the config. Fails on the first one that does not parse.
+func FromConfig(cfg config.Config) ([]Rule, error) {
+	rules := make([]Rule, 0, len(cfg.Alerts))
+	for i, a := range cfg.Alerts {
+		name := a.Name
+		if name == "" {
+			name = fmt.Sprintf("alert %d", i+1)
+		}
+		when, err := Parse(a.When)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		rules = append(rules, Rule{Name: name, Location: a.Location, When: when})
+	}
+	return rules, nil
+}
+
+var (
+	orPattern  = regexp.MustCompile(`(?i)\s+or\s+`)
+	andPattern = regexp.MustCompile(`(?i)\s+and\s+`)
+	// e.g. `wind_gusts_10m > 60 within 6h`
+	conditionPattern = regexp.MustCompile(`(?i)^([a-z0-9_]+)\s*(>=|<=|==|!=|>|<)\s*(-?[0-9]+(?:\.[0-9]+)?)(?:\s+within\s+([0-9]+)\s*h)?$`)
+)
+
+// Parse an expression such as
+//
+//	wind_gusts_10m > 60 within 6h or precipitation_probability >= 70 within 6h
+//
+// Variables are the names of hourly variables of the Open-Meteo Forecast API
+// known to openmeteo.HourlyVariables, since the API rejects any other.
+func Parse(text string) (Expression, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, errors.New("empty condition")
+	}
+
+	var expr Expression
+	for _, group := range orPattern.Split(text, -1) {
+		var conditions []Condition
+		for _, part := range andPattern.Split(strings.TrimSpace(group), -1) {
+			condition, err := parseCondition(strings.TrimSpace(part))
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, condition)
+		}
+		expr = append(expr, conditions)
+	}
+	return expr, nil
+}
+
+func parseCondition(text string) (Condition, error) {
+	parts := conditionPattern.FindStringSubmatch(text)
+	if parts == nil {
+		return Condition{}, fmt.Errorf("cannot read %q: expected e.g. \"wind_gusts_10m > 60 within 6h\"", text)
+	}
+
+	variable := openmeteo.HourlyWeatherVariables(strings.ToLower(parts[1]))
+	if !slices.Contains(openmeteo.HourlyVariables, variable) {
+		return Condition{}, fmt.Errorf("unknown variable %q in %q: use %s", parts[1], text, variableNames())
+	}
+	threshold, err := strconv.ParseFloat(parts[3], 64)
+	if err != nil {
+		return Condition{}, err
+	}
+	window := DEFAULT_WINDOW
+	if parts[4] != "" {
+		hours, err := strconv.Atoi(parts[4])
+		if err != nil || hours == 0 {
+			return Condition{}, fmt.Errorf("window of %q must be at least 1h", text)
+		}
+		window = time.Duration(hours) * time.Hour
+	}
+
+	return Condition{
+		Variable:  variable,
+		Operator:  parts[2],
+		Threshold: threshold,
+		Window:    window,
+	}, nil
+}
+
+func variableNames() string {
+	names := make([]string, len(openmeteo.HourlyVariables))
+	for i, v := range openmeteo.HourlyVariables {
+		names[i] = string(v)
+	}
+	return strings.Join(names, ", ")
+}
+
+// Hourly variables the rules need from the forecast, without repeats.
+func Variables(rules []Rule) []openmeteo.HourlyWeatherVariables {
+	var variables []openmeteo.HourlyWeatherVariables
+	for _, rule := range rules {
+		for _, group := range rule.When {
+			for _, c := range group {
+				if !slices.Contains(variables, c.Variable) {
+					variables = append(variables, c.Variable)
+				}
+			}
+		}
+	}
+	slices.Sort(variables)
+	return variables
+}
+
+// Whether the rule watches the location. Rules without a location watch all of them.
+func (r Rule) AppliesTo(loc openmeteo.GeocodingResult) bool {
+	return r.Location == "" || location.Matches(r.Location, loc)
+}
+
+// Rules watching the location that hold in its hourly forecast, from the hour under way.
+func Evaluate(rules []Rule, loc openmeteo.GeocodingResult, res openmeteo.ForecastResponse, now time.Time) []Alert {
+	var alerts []Alert
+	for _, rule := range rules {
+		if !rule.AppliesTo(loc) {
+			continue
+		}
+		if a, ok := rule.Check(loc, res, now); ok {
+			alerts = append(alerts, a)
+		}
+	}
+	return alerts
+}
+
+// Check the rule against the forecast of a location, whichever location it watches.
+func (r Rule) Check(loc openmeteo.GeocodingResult, res openmeteo.ForecastResponse, now time.Time) (Alert, bool) {
+	matches, ok := r.When.holds(res, now)
+	if !ok {
+		return Alert{}, false
+	}
+	return Alert{Rule: r, Location: loc, Matches: matches}, true
+}
+
+// Matches of the first group where every condition holds.
+func (e Expression) holds(res openmeteo.ForecastResponse, now time.Time) ([]Match, bool) {
+	for _, group := range e {
+		matches := make([]Match, 0, len(group))
+		for _, c := range group {
+			match, ok := c.holds(res, now)
+			if !ok {
+				break
+			}
+			matches = append(matches, match)
+		}
+		if len(matches) == len(group) {
+			return matches, true
+		}
+	}
+	return nil, false
+}
+
+// First hour of the window where the condition holds.
+// The window starts at the hour under way, so a 1h window is that hour alone.
+func (c Condition) holds(res openmeteo.ForecastResponse, now time.Time) (Match, bool) {
+	unit, _ := res.HourlyUnits[string(c.Variable)].(string)
+	var end time.Time
+	for i, stamp := range openmeteo.SeriesTimes(res.Hourly) {
+		at, err := time.ParseInLocation(openmeteo.TIME_LAYOUT, stamp, res.Location())
+		if err != nil || !at.Add(time.Hour).After(now) {
+			continue
+		}
+		if end.IsZero() {
+			end = at.Add(c.Window)
+		}
+		if !at.Before(end) {
+			break
+		}
+		value, ok := openmeteo.SeriesValue(res.Hourly, string(c.Variable), i)
+		if ok && c.compare(value) {
+			return Match{Condition: c, Value: value, Unit: unit, At: at}, true
+		}
+	}
+	return Match{}, false
+}
+
+func (c Condition) compare(value float64) bool {
+	switch c.Operator {
+	case ">":
+		return value > c.Threshold
+	case ">=":
+		return value >= c.Threshold
+	case "<":
+		return value < c.Threshold
+	case "<=":
+		return value <= c.Threshold
+	case "==":
+		return value == c.Threshold
+	case "!=":
+		return value != c.Threshold
+	default:
+		return false
+	}
+}
+
+// What triggered the alert, e.g. "wind_gusts_10m 72.0 km/h at 15:00".
+func (a Alert) Details() string {
+	parts := make([]string, len(a.Matches))
+	for i, m := range a.Matches {
+		parts[i] = fmt.Sprintf("%s %.1f %s at %s", m.Variable, m.Value, m.Unit, m.At.Format("15:04"))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,166 @@
+package alert
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/esferadigital/clima/internal/openmeteo"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want Expression
+		err  string
+	}{
+		{
+			name: "single condition takes the hour under way",
+			text: "wind_gusts_10m > 60",
+			want: Expression{{{Variable: "wind_gusts_10m", Operator: ">", Threshold: 60, Window: time.Hour}}},
+		},
+		{
+			name: "within sets the window",
+			text: "precipitation_probability >= 70 within 6h",
+			want: Expression{{{Variable: "precipitation_probability", Operator: ">=", Threshold: 70, Window: 6 * time.Hour}}},
+		},
+		{
+			name: "and binds tighter than or",
+			text: "temperature_2m < 0 and wind_speed_10m > 20 or snowfall > 1 within 12h",
+			want: Expression{
+				{
+					{Variable: "temperature_2m", Operator: "<", Threshold: 0, Window: time.Hour},
+					{Variable: "wind_speed_10m", Operator: ">", Threshold: 20, Window: time.Hour},
+				},
+				{
+					{Variable: "snowfall", Operator: ">", Threshold: 1, Window: 12 * time.Hour},
+				},
+			},
+		},
+		{
+			name: "keywords and names ignore case",
+			text: "Temperature_2m <= -5.5 AND uv_index != 0 WITHIN 3h",
+			want: Expression{{
+				{Variable: "temperature_2m", Operator: "<=", Threshold: -5.5, Window: time.Hour},
+				{Variable: "uv_index", Operator: "!=", Threshold: 0, Window: 3 * time.Hour},
+			}},
+		},
+		{name: "empty", text: "  ", err: "empty condition"},
+		{name: "bad operator", text: "wind_gusts_10m => 60", err: "cannot read"},
+		{name: "single equals", text: "weather_code = 95", err: "cannot read"},
+		{name: "missing threshold", text: "wind_gusts_10m >", err: "cannot read"},
+		{name: "zero window", text: "wind_gusts_10m > 60 within 0h", err: "at least 1h"},
+		{name: "unknown variable", text: "wind_gust_10m > 60", err: `unknown variable "wind_gust_10m"`},
+		{name: "unknown variable in a later group", text: "snowfall > 1 or visibility < 100", err: `unknown variable "visibility"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.text)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("Parse(%q) error = %v, want one containing %q", tt.text, err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.text, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Parse(%q) = %+v, want %+v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVariables(t *testing.T) {
+	a, _ := Parse("wind_gusts_10m > 60 or precipitation_probability > 70")
+	b, _ := Parse("wind_gusts_10m > 90 and temperature_2m < 0")
+	got := Variables([]Rule{{When: a}, {When: b}})
+	want := []openmeteo.HourlyWeatherVariables{"precipitation_probability", "temperature_2m", "wind_gusts_10m"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Variables = %v, want %v", got, want)
+	}
+}
+
+// Hourly gusts from 12:00 UTC, one value per hour.
+func gusts(values ...any) openmeteo.ForecastResponse {
+	start := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	times := make([]any, len(values))
+	for i := range values {
+		times[i] = start.Add(time.Duration(i) * time.Hour).Format(openmeteo.TIME_LAYOUT)
+	}
+	return openmeteo.ForecastResponse{
+		Timezone:    "UTC",
+		HourlyUnits: map[string]any{"wind_gusts_10m": "km/h"},
+		Hourly:      map[string]any{"time": times, "wind_gusts_10m": values},
+	}
+}
+
+func TestCheckWindow(t *testing.T) {
+	// 12:30, so the 12:00 hour is under way
+	now := time.Date(2026, 10, 18, 12, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		when string
+		res  openmeteo.ForecastResponse
+		ok   bool
+		at   int
+	}{
+		{name: "hour under way", when: "wind_gusts_10m > 60", res: gusts(70.0, 10.0), ok: true, at: 12},
+		{name: "next hour is outside the default window", when: "wind_gusts_10m > 60", res: gusts(10.0, 70.0), ok: false},
+		{name: "inside the window", when: "wind_gusts_10m > 60 within 3h", res: gusts(10.0, 20.0, 70.0), ok: true, at: 14},
+		{name: "past the window", when: "wind_gusts_10m > 60 within 3h", res: gusts(10.0, 20.0, 30.0, 70.0), ok: false},
+		{name: "first matching hour", when: "wind_gusts_10m >= 50 within 6h", res: gusts(10.0, 55.0, 80.0), ok: true, at: 13},
+		{name: "missing values are skipped", when: "wind_gusts_10m > 60 within 3h", res: gusts(nil, 70.0), ok: true, at: 13},
+		{name: "no hourly data", when: "wind_gusts_10m > 60", res: openmeteo.ForecastResponse{}, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			when, err := Parse(tt.when)
+			if err != nil {
+				t.Fatal(err)
+			}
+			a, ok := Rule{Name: "gusts", When: when}.Check(openmeteo.GeocodingResult{}, tt.res, now)
+			if ok != tt.ok {
+				t.Fatalf("Check ok = %v, want %v", ok, tt.ok)
+			}
+			if ok && a.Matches[0].At.Hour() != tt.at {
+				t.Fatalf("matched at %s, want %d:00", a.Matches[0].At.Format("15:04"), tt.at)
+			}
+		})
+	}
+}
+
+func TestCheckGroups(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	res := gusts(70.0)
+	res.HourlyUnits["temperature_2m"] = "°C"
+	res.Hourly["temperature_2m"] = []any{5.0}
+
+	tests := []struct {
+		when    string
+		ok      bool
+		matches int
+	}{
+		{when: "wind_gusts_10m > 60 and temperature_2m < 0", ok: false},
+		{when: "wind_gusts_10m > 60 and temperature_2m < 10", ok: true, matches: 2},
+		{when: "temperature_2m < 0 or wind_gusts_10m > 60", ok: true, matches: 1},
+		{when: "temperature_2m < 0 and wind_gusts_10m > 60 or wind_gusts_10m > 90", ok: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.when, func(t *testing.T) {
+			when, err := Parse(tt.when)
+			if err != nil {
+				t.Fatal(err)
+			}
+			a, ok := Rule{When: when}.Check(openmeteo.GeocodingResult{}, res, now)
+			if ok != tt.ok || len(a.Matches) != tt.matches {
+				t.Fatalf("Check = %v with %d matches, want %v with %d", ok, len(a.Matches), tt.ok, tt.matches)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package astro
+
+import (
+	"math"
+	"time"
+)
+
+// Average length of a lunar cycle, in days.
+const SYNODIC_MONTH = 29.530588853
+
+// Julian date of a known new moon, 2000-01-06 18:14 UTC.
+const referenceNewMoon = 2451550.26
+
+// Moon phase at an instant.
+type MoonPhase struct {
+	// Days since the last new moon.
+	Age float64
+	// Lit fraction of the disc, from 0 to 1.
+	Illumination float64
+	Name         string
+}
+
+var phaseNames = []string{
+	"New moon",
+	"Waxing crescent",
+	"First quarter",
+	"Waxing gibbous",
+	"Full moon",
+	"Waning gibbous",
+	"Last quarter",
+	"Waning crescent",
+}
+
+// Approximate the moon phase from the mean synodic month.
+// Good to within about a day, enough to name the phase.
+func Moon(t time.Time) MoonPhase {
+	cycle := math.Mod((toJulian(t)-referenceNewMoon)/SYNODIC_MONTH, 1)
+	if cycle < 0 {
+		cycle++
+	}
+
+	// Eight phases, each centered on its point of the cycle
+	index := int(math.Floor(cycle*8+0.5)) % 8
+
+	return MoonPhase{
+		Age:          cycle * SYNODIC_MONTH,
+		Illumination: (1 - math.Cos(2*math.Pi*cycle)) / 2,
+		Name:         phaseNames[index],
+	}
+}
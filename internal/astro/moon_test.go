@@ -0,0 +1,36 @@
+package astro
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMoon(t *testing.T) {
+	// Phases of January 2024, from published tables
+	tests := []struct {
+		at       time.Time
+		name     string
+		min, max float64
+	}{
+		{time.Date(2024, 1, 11, 11, 57, 0, 0, time.UTC), "New moon", 0, 0.02},
+		{time.Date(2024, 1, 14, 12, 0, 0, 0, time.UTC), "Waxing crescent", 0.05, 0.45},
+		{time.Date(2024, 1, 18, 3, 53, 0, 0, time.UTC), "First quarter", 0.4, 0.6},
+		{time.Date(2024, 1, 25, 17, 54, 0, 0, time.UTC), "Full moon", 0.98, 1},
+		{time.Date(2024, 2, 2, 23, 18, 0, 0, time.UTC), "Last quarter", 0.4, 0.6},
+		// Before the reference new moon
+		{time.Date(1999, 12, 22, 17, 31, 0, 0, time.UTC), "Full moon", 0.98, 1},
+	}
+
+	for _, tt := range tests {
+		got := Moon(tt.at)
+		if got.Name != tt.name {
+			t.Errorf("Moon(%s) = %q, want %q", tt.at.Format(time.DateOnly), got.Name, tt.name)
+		}
+		if got.Illumination < tt.min || got.Illumination > tt.max {
+			t.Errorf("Moon(%s) illumination = %.2f, want between %g and %g", tt.at.Format(time.DateOnly), got.Illumination, tt.min, tt.max)
+		}
+		if got.Age < 0 || got.Age >= SYNODIC_MONTH {
+			t.Errorf("Moon(%s) age = %.2f, want within a synodic month", tt.at.Format(time.DateOnly), got.Age)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package astro
+
+import (
+	"math"
+	"time"
+)
+
+// Sun altitudes, in degrees, that define each event.
+// Sunrise and sunset account for refraction and the radius of the solar disc.
+const (
+	SUNRISE_ALTITUDE  = -0.833
+	CIVIL_ALTITUDE    = -6.0
+	NAUTICAL_ALTITUDE = -12.0
+)
+
+const (
+	julianUnixEpoch = 2440587.5
+	julianJ2000     = 2451545.0
+	obliquity       = 23.4397
+)
+
+// Sun events of a single day at a location.
+// Events that do not happen that day, as in polar day or night, are zero.
+type SunEvents struct {
+	NauticalDawn time.Time
+	CivilDawn    time.Time
+	Sunrise      time.Time
+	SolarNoon    time.Time
+	Sunset       time.Time
+	CivilDusk    time.Time
+	NauticalDusk time.Time
+	// Time between sunrise and sunset. 24h in polar day, 0 in polar night.
+	DayLength time.Duration
+}
+
+// Compute the sun events for the calendar day of `date`, in its location.
+// Uses the sunrise equation, accurate to about a minute outside polar regions.
+// https://en.wikipedia.org/wiki/Sunrise_equation
+func Sun(date time.Time, lat float64, long float64) SunEvents {
+	y, m, d := date.Date()
+	midnight := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	n := math.Ceil(toJulian(midnight) - julianJ2000 + 0.0008)
+
+	meanNoon := n - long/360
+	anomaly := math.Mod(357.5291+0.98560028*meanNoon, 360)
+	center := 1.9148*sin(anomaly) + 0.0200*sin(2*anomaly) + 0.0003*sin(3*anomaly)
+	eclipticLong := math.Mod(anomaly+center+180+102.9372, 360)
+	transit := julianJ2000 + meanNoon + 0.0053*sin(anomaly) - 0.0069*sin(2*eclipticLong)
+	declination := asin(sin(eclipticLong) * sin(obliquity))
+
+	loc := date.Location()
+	events := SunEvents{SolarNoon: fromJulian(transit).In(loc)}
+
+	// Julian dates for the sun crossing `altitude` before and after noon
+	crossing := func(altitude float64) (time.Time, time.Time, float64) {
+		cosHourAngle := (sin(altitude) - sin(lat)*sin(declination)) / (cos(lat) * cos(declination))
+		if cosHourAngle < -1 || cosHourAngle > 1 {
+			return time.Time{}, time.Time{}, cosHourAngle
+		}
+		hourAngle := acos(cosHourAngle)
+		return fromJulian(transit - hourAngle/360).In(loc), fromJulian(transit + hourAngle/360).In(loc), cosHourAngle
+	}
+
+	var cosSunrise float64
+	events.Sunrise, events.Sunset, cosSunrise = crossing(SUNRISE_ALTITUDE)
+	events.CivilDawn, events.CivilDusk, _ = crossing(CIVIL_ALTITUDE)
+	events.NauticalDawn, events.NauticalDusk, _ = crossing(NAUTICAL_ALTITUDE)
+
+	switch {
+	case cosSunrise < -1:
+		events.DayLength = 24 * time.Hour
+	case cosSunrise > 1:
+		events.DayLength = 0
+	default:
+		events.DayLength = events.Sunset.Sub(events.Sunrise)
+	}
+
+	return events
+}
+
+// Altitude of the sun above the horizon at an instant, in degrees.
+// Negative values mean the sun is below the horizon.
+func SolarElevation(t time.Time, lat float64, long float64) float64 {
+	d := toJulian(t) - julianJ2000
+
+	anomaly := 357.529 + 0.98560028*d
+	meanLong := 280.459 + 0.98564736*d
+	eclipticLong := meanLong + 1.915*sin(anomaly) + 0.020*sin(2*anomaly)
+	tilt := 23.439 - 0.00000036*d
+
+	rightAscension := math.Atan2(cos(tilt)*sin(eclipticLong), cos(eclipticLong)) * 180 / math.Pi
+	declination := asin(sin(tilt) * sin(eclipticLong))
+
+	siderealHours := math.Mod(18.697374558+24.06570982441908*d, 24)
+	hourAngle := siderealHours*15 + long - rightAscension
+
+	return asin(sin(lat)*sin(declination) + cos(lat)*cos(declination)*cos(hourAngle))
+}
+
+func toJulian(t time.Time) float64 {
+	return float64(t.Unix())/86400 + julianUnixEpoch
+}
+
+func fromJulian(j float64) time.Time {
+	seconds := (j - julianUnixEpoch) * 86400
+	return time.Unix(int64(math.Round(seconds)), 0)
+}
+
+// Trigonometry in degrees, like the formulas above.
+
+func sin(deg float64) float64 {
+	return math.Sin(deg * math.Pi / 180)
+}
+
+func cos(deg float64) float64 {
+	return math.Cos(deg * math.Pi / 180)
+}
+
+func asin(x float64) float64 {
+	return math.Asin(x) * 180 / math.Pi
+}
+
+func acos(x float64) float64 {
+	return math.Acos(x) * 180 / math.Pi
+}
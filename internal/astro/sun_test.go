@@ -0,0 +1,78 @@
+package astro
+
+import (
+	"testing"
+	"time"
+)
+
+// Published sun times are given to the minute.
+const SUN_TOLERANCE = 2 * time.Minute
+
+func TestSun(t *testing.T) {
+	bst := time.FixedZone("BST", 3600)
+	london := Sun(time.Date(2024, 6, 21, 12, 0, 0, 0, bst), 51.5074, -0.1278)
+
+	times := []struct {
+		name string
+		got  time.Time
+		want time.Time
+	}{
+		{"sunrise", london.Sunrise, time.Date(2024, 6, 21, 4, 43, 0, 0, bst)},
+		{"solar noon", london.SolarNoon, time.Date(2024, 6, 21, 13, 2, 0, 0, bst)},
+		{"sunset", london.Sunset, time.Date(2024, 6, 21, 21, 21, 0, 0, bst)},
+	}
+	for _, tt := range times {
+		if diff := tt.got.Sub(tt.want).Abs(); diff > SUN_TOLERANCE {
+			t.Errorf("London %s = %s, want %s", tt.name, tt.got.Format(time.TimeOnly), tt.want.Format(time.TimeOnly))
+		}
+		if tt.got.Location() != bst {
+			t.Errorf("London %s in %s, want the location of the date", tt.name, tt.got.Location())
+		}
+	}
+	if !(london.NauticalDawn.Before(london.CivilDawn) && london.CivilDawn.Before(london.Sunrise)) {
+		t.Errorf("dawns out of order: nautical %s, civil %s, sunrise %s", london.NauticalDawn, london.CivilDawn, london.Sunrise)
+	}
+	if diff := (london.DayLength - (16*time.Hour + 38*time.Minute)).Abs(); diff > SUN_TOLERANCE {
+		t.Errorf("London day length = %s, want 16h38m", london.DayLength)
+	}
+
+	polar := []struct {
+		name   string
+		date   time.Time
+		length time.Duration
+	}{
+		{"polar day", time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC), 24 * time.Hour},
+		{"polar night", time.Date(2024, 12, 21, 12, 0, 0, 0, time.UTC), 0},
+	}
+	for _, tt := range polar {
+		t.Run(tt.name, func(t *testing.T) {
+			tromso := Sun(tt.date, 69.65, 18.96)
+			if !tromso.Sunrise.IsZero() || !tromso.Sunset.IsZero() {
+				t.Errorf("sunrise %s and sunset %s, want none", tromso.Sunrise, tromso.Sunset)
+			}
+			if tromso.DayLength != tt.length {
+				t.Errorf("day length = %s, want %s", tromso.DayLength, tt.length)
+			}
+		})
+	}
+}
+
+func TestSolarElevation(t *testing.T) {
+	tests := []struct {
+		name     string
+		at       time.Time
+		min, max float64
+	}{
+		{"overhead at noon on the equinox", time.Date(2024, 3, 20, 12, 7, 0, 0, time.UTC), 85, 90},
+		{"below the horizon at midnight", time.Date(2024, 3, 20, 0, 7, 0, 0, time.UTC), -90, -85},
+		{"near the horizon at sunrise", time.Date(2024, 3, 20, 6, 7, 0, 0, time.UTC), -2, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SolarElevation(tt.at, 0, 0); got < tt.min || got > tt.max {
+				t.Errorf("SolarElevation = %.2f, want between %g and %g", got, tt.min, tt.max)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package climate
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/esferadigital/clima/internal/openmeteo"
+)
+
+// Reference period for normals, the current WMO climatological standard.
+const (
+	NORMALS_FIRST_YEAR = 1991
+	NORMALS_LAST_YEAR  = 2020
+)
+
+// Days on each side of a date averaged into its normal.
+// Smooths out the noise of 30 samples per calendar day.
+const SMOOTHING_DAYS = 7
+
+// Typical and extreme temperatures for one calendar day.
+type Day struct {
+	Min       float64 `json:"min"`
+	Max       float64 `json:"max"`
+	RecordMin float64 `json:"record_min"`
+	RecordMax float64 `json:"record_max"`
+}
+
+// Normals for every calendar day at a location.
+// Records cover everything from the reference period up to `Through`.
+type Normals struct {
+	Latitude  float64                   `json:"latitude"`
+	Longitude float64                   `json:"longitude"`
+	Unit      openmeteo.TemperatureUnit `json:"unit"`
+	Through   int                       `json:"through"`
+	Days      [365]Day                  `json:"days"`
+}
+
+// How a forecast value compares with the normal of its day.
+type Anomaly struct {
+	Delta  float64
+	Record bool
+}
+
+// Normals for the calendar day of the date. February 29 uses February 28.
+func (n Normals) For(date time.Time) Day {
+	return n.Days[dayIndex(date)]
+}
+
+// Compare a maximum temperature with the normal. Beating the record high counts as a record.
+func (d Day) MaxAnomaly(max float64) Anomaly {
+	return Anomaly{Delta: max - d.Max, Record: max > d.RecordMax}
+}
+
+// Compare a minimum temperature with the normal. Going under the record low counts as a record.
+func (d Day) MinAnomaly(min float64) Anomaly {
+	return Anomaly{Delta: min - d.Min, Record: min < d.RecordMin}
+}
+
+// Get the normals for a location, from the cache when possible.
+// The first call per location downloads several decades of daily data, so it is slow.
+func Load(lat float64, long float64, unit openmeteo.TemperatureUnit) (Normals, error) {
+	if unit == "" {
+		unit = openmeteo.Celsius
+	}
+	through := time.Now().Year() - 1
+
+	path, err := cachePath(lat, long, unit)
+	if err != nil {
+		return Normals{}, err
+	}
+	if normals, err := readCache(path); err == nil && normals.Through >= through {
+		return normals, nil
+	}
+
+	normals, err := compute(lat, long, unit, through)
+	if err != nil {
+		return Normals{}, err
+	}
+
+	// A failed write only costs a download next time
+	_ = writeCache(path, normals)
+
+	return normals, nil
+}
+
+func compute(lat float64, long float64, unit openmeteo.TemperatureUnit, through int) (Normals, error) {
+	res, err := openmeteo.GetArchive(openmeteo.ArchiveParams{
+		Latitude:  lat,
+		Longitude: long,
+		StartDate: time.Date(NORMALS_FIRST_YEAR, time.January, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(through, time.December, 31, 0, 0, 0, 0, time.UTC),
+		Daily:     []openmeteo.DailyWeatherVariables{openmeteo.Temperature2mMin, openmeteo.Temperature2mMax},
+		Units:     openmeteo.Units{Temperature: unit},
+	})
+	if err != nil {
+		return Normals{}, err
+	}
+
+	var (
+		sumMin, sumMax       [365]float64
+		count                [365]int
+		recordMin, recordMax [365]float64
+	)
+	for i := range recordMin {
+		recordMin[i] = math.Inf(1)
+		recordMax[i] = math.Inf(-1)
+	}
+
+	for i, t := range openmeteo.SeriesTimes(res.Daily) {
+		date, err := time.Parse(time.DateOnly, t)
+		if err != nil {
+			continue
+		}
+		low, okMin := openmeteo.SeriesValue(res.Daily, string(openmeteo.Temperature2mMin), i)
+		high, okMax := openmeteo.SeriesValue(res.Daily, string(openmeteo.Temperature2mMax), i)
+		if !okMin || !okMax {
+			continue
+		}
+
+		day := dayIndex(date)
+		recordMin[day] = math.Min(recordMin[day], low)
+		recordMax[day] = math.Max(recordMax[day], high)
+		if date.Year() <= NORMALS_LAST_YEAR {
+			sumMin[day] += low
+			sumMax[day] += high
+			count[day]++
+		}
+	}
+
+	normals := Normals{Latitude: lat, Longitude: long, Unit: unit, Through: through}
+	for day := range normals.Days {
+		var lows, highs float64
+		var n int
+		for offset := -SMOOTHING_DAYS; offset <= SMOOTHING_DAYS; offset++ {
+			i := (day + offset + 365) % 365
+			lows += sumMin[i]
+			highs += sumMax[i]
+			n += count[i]
+		}
+		if n == 0 {
+			return Normals{}, fmt.Errorf("no archive data for day %d", day+1)
+		}
+		normals.Days[day] = Day{
+			Min:       lows / float64(n),
+			Max:       highs / float64(n),
+			RecordMin: recordMin[day],
+			RecordMax: recordMax[day],
+		}
+	}
+
+	return normals, nil
+}
+
+// Position of the calendar day in a non-leap year.
+func dayIndex(date time.Time) int {
+	month, day := date.Month(), date.Day()
+	if month == time.February && day == 29 {
+		day = 28
+	}
+	return time.Date(2001, month, day, 0, 0, 0, 0, time.UTC).YearDay() - 1
+}
+
+func cachePath(lat float64, long float64, unit openmeteo.TemperatureUnit) (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(cacheDir, "clima", "normals")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	// Two decimals is about 1 km, well within the resolution of the archive
+	return filepath.Join(dir, fmt.Sprintf("%.2f_%.2f_%s.json", lat, long, unit)), nil
+}
+
+func readCache(path string) (Normals, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Normals{}, err
+	}
+
+	var normals Normals
+	if err := json.Unmarshal(data, &normals); err != nil {
+		return Normals{}, err
+	}
+
+	return normals, nil
+}
+
+func writeCache(path string, normals Normals) error {
+	data, err := json.Marshal(normals)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
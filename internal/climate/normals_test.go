@@ -0,0 +1,52 @@
+package climate
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDayIndex(t *testing.T) {
+	tests := []struct {
+		name string
+		date time.Time
+		want int
+	}{
+		{"first day", time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC), 0},
+		{"end of February", time.Date(2023, time.February, 28, 0, 0, 0, 0, time.UTC), 58},
+		{"February 29 is February 28", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC), 58},
+		{"March 1 of a leap year", time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), 59},
+		{"March 1 of a common year", time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC), 59},
+		{"last day of a leap year", time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC), 364},
+		{"calendar day of the date, not of UTC", time.Date(2024, time.January, 1, 23, 0, 0, 0, time.FixedZone("ECT", -5*3600)), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dayIndex(tt.date); got != tt.want {
+				t.Errorf("dayIndex(%s) = %d, want %d", tt.date.Format(time.DateOnly), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnomaly(t *testing.T) {
+	day := Day{Min: 10, Max: 20, RecordMin: 2, RecordMax: 28}
+
+	tests := []struct {
+		name string
+		got  Anomaly
+		want Anomaly
+	}{
+		{"warmer than normal", day.MaxAnomaly(23.5), Anomaly{Delta: 3.5}},
+		{"record high", day.MaxAnomaly(29), Anomaly{Delta: 9, Record: true}},
+		{"equal to the record is not one", day.MaxAnomaly(28), Anomaly{Delta: 8}},
+		{"colder than normal", day.MinAnomaly(7), Anomaly{Delta: -3}},
+		{"record low", day.MinAnomaly(1), Anomaly{Delta: -9, Record: true}},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %+v, want %+v", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/esferadigital/clima/internal/openmeteo"
+)
+
+const CONFIG_FILE = "config.json"
+
+// Forecast endpoint entry. Built-in providers only need a name,
+// any other Open-Meteo compatible endpoint (e.g. a self-hosted instance) needs a URL.
+type ProviderConfig struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+// Keys are named as Bubble Tea reports them, e.g. "up", "esc" or "ctrl+r".
+type KeysConfig struct {
+	Preset   string              `json:"preset"`
+	Bindings map[string][]string `json:"bindings"`
+}
+
+// Where the current position comes from, tried in this order.
+type PositionConfig struct {
+	// Address of a gpsd, e.g. "localhost:2947". Empty skips it.
+	Gpsd string `json:"gpsd"`
+	// GeoLite2-City or DB-IP City database locating the public IP. Empty skips it.
+	IPDatabase string `json:"ip_database"`
+	// Public IP to locate. Empty asks IPEndpoint for it.
+	IP string `json:"ip"`
+	// URL answering with the public IP as plain text.
+	IPEndpoint string `json:"ip_endpoint"`
+	// Position used when there is no fix, as "lat,lon" or a geo URI.
+	Static string `json:"static"`
+}
+
+// Rule warning about the forecast, e.g. gusts over 60 km/h in the next 6 hours.
+// See the alert package for the syntax of When.
+type AlertConfig struct {
+	Name string `json:"name"`
+	// Location the rule watches: a name, an alias or "lat,lon". Empty applies to any location in the TUI.
+	Location string `json:"location"`
+	When     string `json:"when"`
+}
+
+// Hook run on forecast events, either a URL that gets the event POSTed as JSON
+// or a command that gets it on stdin.
+type HookConfig struct {
+	// Events that run the hook: fetch, category or alert. Empty runs it on all of them.
+	Events  []string `json:"events"`
+	URL     string   `json:"url,omitempty"`
+	Command []string `json:"command,omitempty"`
+	// Seconds each attempt may take, 10 when unset.
+	TimeoutSeconds int `json:"timeout_seconds"`
+	// Attempts after the first one fails.
+	Retries int `json:"retries"`
+	// Report the event instead of delivering it.
+	DryRun bool `json:"dry_run"`
+}
+
+// Locations whose current conditions are exported as metrics by `clima serve`.
+type MetricsConfig struct {
+	// Names, aliases or "lat,lon", used as the location label. Empty exports the home location.
+	Locations []string `json:"locations"`
+}
+
+// User settings read from `~/.config/clima/config.json`.
+// Missing fields keep their default values.
+type Config struct {
+	// Forecast providers, tried in order until one succeeds.
+	Providers []ProviderConfig `json:"providers"`
+	// Weather models shown side by side in the comparison screen.
+	CompareModels []openmeteo.WeatherModel `json:"compare_models"`
+	// Units for forecasts and history. Empty fields use the API defaults.
+	Units openmeteo.Units `json:"units"`
+	// Weather icon style: ascii, emoji or nerd.
+	Icons string `json:"icons"`
+	// Color theme: auto, dark, light, high-contrast or no-color.
+	Theme string `json:"theme"`
+	// Colors replacing those of the theme, by role, e.g. {"accent": "#ff8700"}.
+	ThemeColors map[string]string `json:"theme_colors"`
+	// Key bindings: a preset, arrows or vim, and keys by action replacing it.
+	Keys KeysConfig `json:"keys"`
+	// Minutes between background refreshes of the forecast. Zero disables them.
+	RefreshMinutes int `json:"refresh_minutes"`
+	// Minutes a fetched forecast is reused, e.g. for the recent list, before fetching it again.
+	CacheMinutes int `json:"cache_minutes"`
+	// Sources of the current position, offered at the top of the recent list when set.
+	Position PositionConfig `json:"position"`
+	// Rules checked on every forecast fetch, shown as a banner and sent by `clima watch`.
+	Alerts []AlertConfig `json:"alerts"`
+	// Command run by `clima watch` for each alert instead of a desktop notification.
+	AlertCommand []string `json:"alert_command"`
+	// Hooks run when a forecast is fetched, its conditions change category or an alert starts to hold.
+	Hooks []HookConfig `json:"hooks"`
+	// Locations exported as metrics by `clima serve`.
+	Metrics MetricsConfig `json:"metrics"`
+	// Location opened at startup instead of the recent list: a name, an alias or "lat,lon".
+	Home string `json:"home"`
+	// Interface language, e.g. "es". Empty detects it from LANG.
+	Locale string `json:"locale"`
+}
+
+func Default() Config {
+	return Config{
+		Providers: []ProviderConfig{
+			{Name: "open-meteo"},
+		},
+		CompareModels: []openmeteo.WeatherModel{
+			openmeteo.ModelECMWF,
+			openmeteo.ModelGFS,
+			openmeteo.ModelICON,
+		},
+		Icons: "ascii",
+		Theme: "auto",
+		// Current conditions are updated every 15 minutes
+		RefreshMinutes: 15,
+		CacheMinutes:   10,
+	}
+}
+
+// Directory where clima keeps its configuration and stored data.
+func Dir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	configDir := filepath.Join(homeDir, ".config", "clima")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return "", err
+	}
+
+	return configDir, nil
+}
+
+func Load() (Config, error) {
+	cfg := Default()
+
+	dir, err := Dir()
+	if err != nil {
+		return cfg, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, CONFIG_FILE))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return cfg, err
+	}
+
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, err
+	}
+
+	return cfg, nil
+}
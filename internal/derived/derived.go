@@ -0,0 +1,211 @@
+package derived
+
+import (
+	"math"
+
+	"github.com/esferadigital/clima/internal/openmeteo"
+)
+
+// Metrics derived from the current weather, in the units of the response.
+type Conditions struct {
+	// 16-point compass direction the wind blows from, e.g. "WSW".
+	Compass  string
+	Beaufort Force
+	DewPoint float64
+	// Only set in warm weather, where it is meaningful.
+	HeatIndex    float64
+	HasHeatIndex bool
+	Humidex      float64
+	HasHumidex   bool
+	// Only set in cold and windy weather, where it is meaningful.
+	WindChill    float64
+	HasWindChill bool
+	Comfort      string
+}
+
+// Derive metrics from the current weather of a forecast.
+// Requires temperature, humidity, wind speed and wind direction.
+func FromForecast(res openmeteo.ForecastResponse) (Conditions, bool) {
+	temp, okTemp := res.Current[string(openmeteo.Temperature2m)].(float64)
+	humidity, okHumidity := res.Current[string(openmeteo.RelativeHumidity2m)].(float64)
+	speed, okSpeed := res.Current[string(openmeteo.WindSpeed10m)].(float64)
+	direction, okDirection := res.Current[string(openmeteo.WindDirection10m)].(float64)
+	if !okTemp || !okHumidity || !okSpeed || !okDirection {
+		return Conditions{}, false
+	}
+	tempUnit, _ := res.CurrentUnits[string(openmeteo.Temperature2m)].(string)
+	speedUnit, _ := res.CurrentUnits[string(openmeteo.WindSpeed10m)].(string)
+
+	celsius := ToCelsius(temp, tempUnit)
+	kmh := ToKMH(speed, speedUnit)
+	dewPoint := DewPoint(celsius, humidity)
+
+	c := Conditions{
+		Compass:  Compass(direction),
+		Beaufort: Beaufort(kmh),
+		DewPoint: FromCelsius(dewPoint, tempUnit),
+	}
+	if heatIndex, ok := HeatIndex(celsius, humidity); ok {
+		c.HeatIndex, c.HasHeatIndex = FromCelsius(heatIndex, tempUnit), true
+	}
+	if humidex, ok := Humidex(celsius, dewPoint); ok {
+		c.Humidex, c.HasHumidex = FromCelsius(humidex, tempUnit), true
+	}
+	if windChill, ok := WindChill(celsius, kmh); ok {
+		c.WindChill, c.HasWindChill = FromCelsius(windChill, tempUnit), true
+	}
+
+	feels := celsius
+	if c.HasHeatIndex {
+		feels = ToCelsius(c.HeatIndex, tempUnit)
+	} else if c.HasWindChill {
+		feels = ToCelsius(c.WindChill, tempUnit)
+	}
+	c.Comfort = Comfort(feels, dewPoint)
+
+	return c, true
+}
+
+var compassPoints = []string{
+	"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE",
+	"S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW",
+}
+
+// Name the 16-point compass direction of a bearing in degrees.
+func Compass(degrees float64) string {
+	i := int(math.Round(math.Mod(math.Mod(degrees, 360)+360, 360)/22.5)) % 16
+	return compassPoints[i]
+}
+
+// Wind force on the Beaufort scale.
+type Force struct {
+	Number      int
+	Description string
+}
+
+// Upper wind speed of each force, in km/h.
+var beaufortLimits = []float64{1, 6, 12, 20, 29, 39, 50, 62, 75, 89, 103, 118}
+
+var beaufortNames = []string{
+	"Calm",
+	"Light air",
+	"Light breeze",
+	"Gentle breeze",
+	"Moderate breeze",
+	"Fresh breeze",
+	"Strong breeze",
+	"Near gale",
+	"Gale",
+	"Strong gale",
+	"Storm",
+	"Violent storm",
+	"Hurricane force",
+}
+
+// Classify a wind speed in km/h on the Beaufort scale.
+func Beaufort(kmh float64) Force {
+	for i, limit := range beaufortLimits {
+		if kmh < limit {
+			return Force{Number: i, Description: beaufortNames[i]}
+		}
+	}
+	return Force{Number: 12, Description: beaufortNames[12]}
+}
+
+// Dew point in °C, from the Magnus formula.
+func DewPoint(celsius float64, humidity float64) float64 {
+	const b, c = 17.625, 243.04
+	gamma := math.Log(math.Max(humidity, 1)/100) + b*celsius/(c+celsius)
+	return c * gamma / (b - gamma)
+}
+
+// US heat index in °C, from the Rothfusz regression.
+// Only defined from about 27 °C, not ok below that.
+func HeatIndex(celsius float64, humidity float64) (float64, bool) {
+	if celsius < 27 {
+		return 0, false
+	}
+	t, rh := celsius*9/5+32, humidity
+	hi := -42.379 + 2.04901523*t + 10.14333127*rh - 0.22475541*t*rh -
+		0.00683783*t*t - 0.05481717*rh*rh + 0.00122874*t*t*rh +
+		0.00085282*t*rh*rh - 0.00000199*t*t*rh*rh
+	return (hi - 32) * 5 / 9, true
+}
+
+// Canadian humidex in °C.
+// Only meaningful from about 20 °C, not ok below that.
+func Humidex(celsius float64, dewPoint float64) (float64, bool) {
+	if celsius < 20 {
+		return 0, false
+	}
+	e := 6.11 * math.Exp(5417.7530*(1/273.16-1/(dewPoint+273.15)))
+	return celsius + 0.5555*(e-10), true
+}
+
+// Wind chill in °C, from the North American formula.
+// Only defined at 10 °C or less with wind over 4.8 km/h, not ok otherwise.
+func WindChill(celsius float64, kmh float64) (float64, bool) {
+	if celsius > 10 || kmh <= 4.8 {
+		return 0, false
+	}
+	v := math.Pow(kmh, 0.16)
+	return 13.12 + 0.6215*celsius - 11.37*v + 0.3965*celsius*v, true
+}
+
+// Describe how the weather feels, from the felt temperature and dew point in °C.
+func Comfort(feels float64, dewPoint float64) string {
+	switch {
+	case feels >= 41:
+		return "Dangerously hot"
+	case feels >= 32:
+		return "Hot"
+	case feels < 0:
+		return "Freezing"
+	case feels < 10:
+		return "Cold"
+	case dewPoint >= 21:
+		return "Oppressive"
+	case dewPoint >= 18:
+		return "Muggy"
+	case feels < 18:
+		return "Cool"
+	case dewPoint >= 16:
+		return "Humid"
+	case dewPoint < 5:
+		return "Dry"
+	default:
+		return "Comfortable"
+	}
+}
+
+// Unit symbols below are the ones sent in the `current_units` of the API.
+
+// Convert a temperature in the given unit to °C.
+func ToCelsius(value float64, unit string) float64 {
+	if unit == "°F" {
+		return (value - 32) * 5 / 9
+	}
+	return value
+}
+
+// Convert a temperature in °C to the given unit.
+func FromCelsius(value float64, unit string) float64 {
+	if unit == "°F" {
+		return value*9/5 + 32
+	}
+	return value
+}
+
+// Convert a wind speed in the given unit to km/h.
+func ToKMH(value float64, unit string) float64 {
+	switch unit {
+	case "m/s":
+		return value * 3.6
+	case "mp/h":
+		return value * 1.609344
+	case "kn":
+		return value * 1.852
+	default:
+		return value
+	}
+}
@@ -0,0 +1,183 @@
+package derived
+
+import (
+	"math"
+	"testing"
+
+	"github.com/esferadigital/clima/internal/openmeteo"
+)
+
+// Formulas are compared with published tables to a tenth of a degree.
+const TOLERANCE = 0.1
+
+func TestCompass(t *testing.T) {
+	tests := []struct {
+		degrees float64
+		want    string
+	}{
+		{0, "N"},
+		{11.2, "N"},
+		{11.25, "NNE"},
+		{90, "E"},
+		{247.5, "WSW"},
+		{349, "N"},
+		{360, "N"},
+		{720, "N"},
+		{-90, "W"},
+	}
+
+	for _, tt := range tests {
+		if got := Compass(tt.degrees); got != tt.want {
+			t.Errorf("Compass(%g) = %q, want %q", tt.degrees, got, tt.want)
+		}
+	}
+}
+
+func TestBeaufort(t *testing.T) {
+	tests := []struct {
+		kmh  float64
+		want int
+	}{
+		{0, 0},
+		{0.9, 0},
+		{1, 1},
+		{19.9, 3},
+		{20, 4},
+		{117.9, 11},
+		{118, 12},
+		{300, 12},
+	}
+
+	for _, tt := range tests {
+		got := Beaufort(tt.kmh)
+		if got.Number != tt.want || got.Description != beaufortNames[tt.want] {
+			t.Errorf("Beaufort(%g) = %+v, want force %d", tt.kmh, got, tt.want)
+		}
+	}
+}
+
+func TestFormulas(t *testing.T) {
+	tests := []struct {
+		name   string
+		got    func() (float64, bool)
+		want   float64
+		wantOk bool
+	}{
+		{
+			name:   "dew point at saturation is the temperature",
+			got:    func() (float64, bool) { return DewPoint(20, 100), true },
+			want:   20,
+			wantOk: true,
+		},
+		{
+			name:   "dew point of a mild day",
+			got:    func() (float64, bool) { return DewPoint(25, 50), true },
+			want:   13.9,
+			wantOk: true,
+		},
+		{
+			name:   "heat index of a humid hot day",
+			got:    func() (float64, bool) { return HeatIndex(32, 70) },
+			want:   40.4,
+			wantOk: true,
+		},
+		{name: "no heat index below 27 °C", got: func() (float64, bool) { return HeatIndex(26.9, 90) }},
+		{
+			name:   "humidex of a humid hot day",
+			got:    func() (float64, bool) { return Humidex(30, 25) },
+			want:   42.3,
+			wantOk: true,
+		},
+		{name: "no humidex below 20 °C", got: func() (float64, bool) { return Humidex(19.9, 15) }},
+		{
+			name:   "wind chill of a cold windy day",
+			got:    func() (float64, bool) { return WindChill(-10, 30) },
+			want:   -19.5,
+			wantOk: true,
+		},
+		{name: "no wind chill above 10 °C", got: func() (float64, bool) { return WindChill(10.1, 30) }},
+		{name: "no wind chill in light wind", got: func() (float64, bool) { return WindChill(-10, 4.8) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.got()
+			if ok != tt.wantOk {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOk)
+			}
+			if ok && math.Abs(got-tt.want) > TOLERANCE {
+				t.Errorf("got %.2f, want %.1f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComfort(t *testing.T) {
+	tests := []struct {
+		feels    float64
+		dewPoint float64
+		want     string
+	}{
+		{45, 10, "Dangerously hot"},
+		{35, 25, "Hot"},
+		{-5, -10, "Freezing"},
+		{5, 0, "Cold"},
+		{25, 22, "Oppressive"},
+		{25, 19, "Muggy"},
+		{15, 10, "Cool"},
+		{25, 17, "Humid"},
+		{25, 2, "Dry"},
+		{22, 12, "Comfortable"},
+	}
+
+	for _, tt := range tests {
+		if got := Comfort(tt.feels, tt.dewPoint); got != tt.want {
+			t.Errorf("Comfort(%g, %g) = %q, want %q", tt.feels, tt.dewPoint, got, tt.want)
+		}
+	}
+}
+
+func TestFromForecast(t *testing.T) {
+	t.Run("converts to the units of the response", func(t *testing.T) {
+		res := openmeteo.ForecastResponse{
+			Current: map[string]any{
+				"temperature_2m":       86.0,
+				"relative_humidity_2m": 70.0,
+				"wind_speed_10m":       10.0,
+				"wind_direction_10m":   180.0,
+			},
+			CurrentUnits: map[string]any{
+				"temperature_2m": "°F",
+				"wind_speed_10m": "m/s",
+			},
+		}
+
+		got, ok := FromForecast(res)
+		if !ok {
+			t.Fatal("not ok")
+		}
+		if got.Compass != "S" {
+			t.Errorf("Compass = %q, want S", got.Compass)
+		}
+		// 10 m/s is 36 km/h
+		if got.Beaufort.Number != 5 {
+			t.Errorf("Beaufort = %d, want 5", got.Beaufort.Number)
+		}
+		// 30 °C at 70% has a dew point of 23.9 °C
+		if math.Abs(got.DewPoint-75.1) > TOLERANCE {
+			t.Errorf("DewPoint = %.2f °F, want 75.1", got.DewPoint)
+		}
+		if !got.HasHeatIndex || !got.HasHumidex || got.HasWindChill {
+			t.Errorf("HasHeatIndex, HasHumidex, HasWindChill = %v, %v, %v, want true, true, false", got.HasHeatIndex, got.HasHumidex, got.HasWindChill)
+		}
+	})
+
+	t.Run("needs wind and humidity", func(t *testing.T) {
+		res := openmeteo.ForecastResponse{
+			Current: map[string]any{"temperature_2m": 20.0},
+		}
+		if _, ok := FromForecast(res); ok {
+			t.Error("ok without wind and humidity")
+		}
+	})
+}
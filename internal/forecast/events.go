@@ -0,0 +1,64 @@
+package forecast
+
+import (
+	"github.com/esferadigital/clima/internal/alert"
+	"github.com/esferadigital/clima/internal/config"
+	"github.com/esferadigital/clima/internal/hook"
+	"github.com/esferadigital/clima/internal/openmeteo"
+)
+
+// Events of a fetch for the hooks. Changes are found by comparing with the
+// previous forecast of the location, if it was cached.
+func events(cfg config.Config, loc openmeteo.GeocodingResult, previous *Cached, fetched Cached) []hook.Event {
+	var events []hook.Event
+	if hook.Wants(cfg, hook.EVENT_FETCH) {
+		events = append(events, hook.Event{
+			Event:    hook.EVENT_FETCH,
+			Time:     fetched.FetchedAt,
+			Location: loc,
+			Forecast: &fetched.Forecast,
+		})
+	}
+	if previous == nil {
+		return events
+	}
+
+	if hook.Wants(cfg, hook.EVENT_CATEGORY) {
+		from, hadCode := category(previous.Forecast)
+		to, hasCode := category(fetched.Forecast)
+		if hadCode && hasCode && from != to {
+			events = append(events, hook.Event{
+				Event:    hook.EVENT_CATEGORY,
+				Time:     fetched.FetchedAt,
+				Location: loc,
+				From:     from,
+				To:       to,
+			})
+		}
+	}
+
+	if hook.Wants(cfg, hook.EVENT_ALERT) {
+		// Validated at startup
+		rules, _ := alert.FromConfig(cfg)
+		for _, rule := range rules {
+			if !rule.AppliesTo(loc) {
+				continue
+			}
+			a, holds := rule.Check(loc, fetched.Forecast, fetched.FetchedAt)
+			_, held := rule.Check(loc, previous.Forecast, previous.FetchedAt)
+			if holds && !held {
+				events = append(events, hook.NewAlertEvent(a, fetched.FetchedAt))
+			}
+		}
+	}
+	return events
+}
+
+// Category of the current conditions of a forecast.
+func category(res openmeteo.ForecastResponse) (openmeteo.Category, bool) {
+	code, ok := res.Current[string(openmeteo.WeatherCode)].(float64)
+	if !ok {
+		return openmeteo.CategoryUnknown, false
+	}
+	return openmeteo.CategoryOf(code), true
+}
@@ -0,0 +1,159 @@
+package forecast
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/esferadigital/clima/internal/alert"
+	"github.com/esferadigital/clima/internal/config"
+	"github.com/esferadigital/clima/internal/hook"
+	"github.com/esferadigital/clima/internal/metrics"
+	"github.com/esferadigital/clima/internal/openmeteo"
+	"github.com/esferadigital/clima/internal/provider"
+)
+
+// Forecast as last fetched for a location.
+type Cached struct {
+	FetchedAt time.Time                  `json:"fetched_at"`
+	Provider  string                     `json:"provider"`
+	Forecast  openmeteo.ForecastResponse `json:"forecast"`
+}
+
+// Whether the forecast is younger than the cache TTL of the config.
+func (c Cached) Fresh(cfg config.Config) bool {
+	return time.Since(c.FetchedAt) < TTL(cfg)
+}
+
+// How long a cached forecast is used before fetching it again.
+func TTL(cfg config.Config) time.Duration {
+	return time.Duration(cfg.CacheMinutes) * time.Minute
+}
+
+// Variables of the forecast for the weather screen, plus the hourly ones of the alert rules.
+// Everything that shares the cache asks for the same ones.
+func Params(cfg config.Config, lat float64, long float64) openmeteo.ForecastParams {
+	// Checked at startup, broken rules only leave out their variables
+	rules, _ := alert.FromConfig(cfg)
+	return openmeteo.ForecastParams{
+		Latitude:  lat,
+		Longitude: long,
+		Units:     cfg.Units,
+		Hourly:    alert.Variables(rules),
+		Current: []openmeteo.CurrentWeatherVariables{
+			openmeteo.Temperature2m,
+			openmeteo.ApparentTemperature,
+			openmeteo.RelativeHumidity2m,
+			openmeteo.IsDay,
+			openmeteo.WeatherCode,
+			openmeteo.WindSpeed10m,
+			openmeteo.WindDirection10m,
+			openmeteo.WindGusts10m,
+			openmeteo.Precipitation,
+			openmeteo.SeaLevelPressure,
+		},
+		Daily: []openmeteo.DailyWeatherVariables{
+			openmeteo.DailyWeatherCode,
+			openmeteo.Temperature2mMin,
+			openmeteo.Temperature2mMax,
+			openmeteo.PrecipitationSum,
+			openmeteo.UVIndexMax,
+			openmeteo.Sunrise,
+			openmeteo.Sunset,
+		},
+		Timezone: "auto",
+	}
+}
+
+// Fetch the forecast of a location from the providers of the config, cache it
+// and run the hooks on what changed since the cached one. Returns the provider that answered.
+func Fetch(cfg config.Config, loc openmeteo.GeocodingResult) (openmeteo.ForecastResponse, provider.Provider, error) {
+	providers, err := provider.FromConfig(cfg)
+	if err != nil {
+		return openmeteo.ForecastResponse{}, provider.Provider{}, err
+	}
+
+	res, source, err := providers.GetForecast(Params(cfg, loc.Latitude, loc.Longitude))
+	if err != nil {
+		return openmeteo.ForecastResponse{}, provider.Provider{}, err
+	}
+	fetched := Cached{FetchedAt: time.Now(), Provider: source.Name, Forecast: res}
+
+	var previous *Cached
+	// A failed write only costs a fetch next time
+	if path, err := cachePath(cfg, loc.Latitude, loc.Longitude); err == nil {
+		if cached, err := readCache(path); err == nil {
+			previous = &cached
+		}
+		_ = writeCache(path, fetched)
+	}
+	hook.Dispatch(cfg, events(cfg, loc, previous, fetched))
+
+	return res, source, nil
+}
+
+// Forecast of a location from the cache while it is fresh, fetched otherwise.
+func Get(cfg config.Config, loc openmeteo.GeocodingResult) (Cached, error) {
+	if cached, err := Load(cfg, loc.Latitude, loc.Longitude); err == nil && cached.Fresh(cfg) {
+		metrics.ObserveCache(true)
+		return cached, nil
+	}
+	metrics.ObserveCache(false)
+	fetchedAt := time.Now()
+	res, source, err := Fetch(cfg, loc)
+	if err != nil {
+		return Cached{}, err
+	}
+	return Cached{FetchedAt: fetchedAt, Provider: source.Name, Forecast: res}, nil
+}
+
+// Last forecast fetched for a location, however old.
+func Load(cfg config.Config, lat float64, long float64) (Cached, error) {
+	path, err := cachePath(cfg, lat, long)
+	if err != nil {
+		return Cached{}, err
+	}
+	return readCache(path)
+}
+
+func cachePath(cfg config.Config, lat float64, long float64) (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(cacheDir, "clima", "forecasts")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	// Units change every value, so each combination has its own entry
+	units := cfg.Units
+	name := fmt.Sprintf("%.4f_%.4f_%s_%s_%s.json", lat, long, units.Temperature, units.WindSpeed, units.Precipitation)
+	return filepath.Join(dir, name), nil
+}
+
+func readCache(path string) (Cached, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Cached{}, err
+	}
+
+	var cached Cached
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return Cached{}, err
+	}
+
+	return cached, nil
+}
+
+func writeCache(path string, cached Cached) error {
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
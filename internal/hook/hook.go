@@ -0,0 +1,219 @@
+package hook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/esferadigital/clima/internal/alert"
+	"github.com/esferadigital/clima/internal/config"
+	"github.com/esferadigital/clima/internal/openmeteo"
+)
+
+// Kinds of events hooks react to.
+const (
+	EVENT_FETCH    = "fetch"
+	EVENT_CATEGORY = "category"
+	EVENT_ALERT    = "alert"
+)
+
+// Time given to each attempt when the config does not set one.
+const DEFAULT_TIMEOUT = 10 * time.Second
+
+// Something that happened to the forecast of a location, sent to hooks as JSON.
+type Event struct {
+	Event    string                    `json:"event"`
+	Time     time.Time                 `json:"time"`
+	Location openmeteo.GeocodingResult `json:"location"`
+	// Forecast that was fetched, on fetch events.
+	Forecast *openmeteo.ForecastResponse `json:"forecast,omitempty"`
+	// Categories of the conditions before and after, on category events.
+	From openmeteo.Category `json:"from,omitempty"`
+	To   openmeteo.Category `json:"to,omitempty"`
+	// Rule that started to hold, on alert events.
+	Alert *AlertEvent `json:"alert,omitempty"`
+}
+
+type AlertEvent struct {
+	Name    string `json:"name"`
+	Details string `json:"details"`
+}
+
+// Event for an alert rule that started to hold.
+func NewAlertEvent(a alert.Alert, now time.Time) Event {
+	return Event{
+		Event:    EVENT_ALERT,
+		Time:     now,
+		Location: a.Location,
+		Alert:    &AlertEvent{Name: a.Rule.Name, Details: a.Details()},
+	}
+}
+
+// Whether any hook of the config reacts to the kind of event.
+func Wants(cfg config.Config, event string) bool {
+	return slices.ContainsFunc(cfg.Hooks, func(h config.HookConfig) bool {
+		return fires(h, event)
+	})
+}
+
+func fires(h config.HookConfig, event string) bool {
+	return len(h.Events) == 0 || slices.Contains(h.Events, event)
+}
+
+// Check the hooks of the config, as done at startup.
+func Validate(cfg config.Config) error {
+	for i, h := range cfg.Hooks {
+		if (h.URL == "") == (len(h.Command) == 0) {
+			return fmt.Errorf("hook %d: set either a url or a command", i+1)
+		}
+		for _, event := range h.Events {
+			if event != EVENT_FETCH && event != EVENT_CATEGORY && event != EVENT_ALERT {
+				return fmt.Errorf("hook %d: unknown event %q: use fetch, category or alert", i+1, event)
+			}
+		}
+		if h.TimeoutSeconds < 0 || h.Retries < 0 {
+			return fmt.Errorf("hook %d: timeout and retries cannot be negative", i+1)
+		}
+	}
+	return nil
+}
+
+var (
+	pending sync.WaitGroup
+	// Deliveries under way, for reports while waiting on them
+	running atomic.Int64
+	// Where dry runs and failures are reported
+	logMu sync.Mutex
+	log   io.Writer = io.Discard
+)
+
+// Report dry runs and failures to w from now on. Nothing is reported by default,
+// since the TUI owns the terminal.
+func SetLog(w io.Writer) {
+	logMu.Lock()
+	defer logMu.Unlock()
+	log = w
+}
+
+func logf(format string, args ...any) {
+	logMu.Lock()
+	defer logMu.Unlock()
+	fmt.Fprintf(log, format+"\n", args...)
+}
+
+// Deliver the events to the hooks that react to them, in the background.
+// Commands that exit afterwards call Wait.
+func Dispatch(cfg config.Config, events []Event) {
+	for _, h := range cfg.Hooks {
+		for _, e := range events {
+			if !fires(h, e.Event) {
+				continue
+			}
+			pending.Add(1)
+			running.Add(1)
+			go func() {
+				defer pending.Done()
+				defer running.Add(-1)
+				if err := deliver(h, e); err != nil {
+					logf("hook %s failed on %s: %v", target(h), e.Event, err)
+				}
+			}()
+		}
+	}
+}
+
+// Number of hook deliveries under way.
+func Pending() int {
+	return int(running.Load())
+}
+
+// Wait for the hooks under way to finish, for at most the timeout.
+// Reports whether they all did. The ones left are abandoned when the program exits.
+func Wait(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		pending.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// Try the hook once plus its retries, waiting twice as long after each failure.
+func deliver(h config.HookConfig, e Event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if h.DryRun {
+		logf("dry run: %s would get %s", target(h), payload)
+		return nil
+	}
+
+	timeout := DEFAULT_TIMEOUT
+	if h.TimeoutSeconds > 0 {
+		timeout = time.Duration(h.TimeoutSeconds) * time.Second
+	}
+	wait := time.Second
+	for attempt := 0; ; attempt++ {
+		if err = run(h, payload, timeout); err == nil || attempt >= h.Retries {
+			return err
+		}
+		time.Sleep(wait)
+		wait *= 2
+	}
+}
+
+// POST the payload to the URL, or run the command with it on stdin.
+func run(h config.HookConfig, payload []byte, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if h.URL != "" {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.URL, bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer res.Body.Close()
+		if res.StatusCode < 200 || res.StatusCode > 299 {
+			return fmt.Errorf("answered %s", res.Status)
+		}
+		return nil
+	}
+
+	cmd := exec.CommandContext(ctx, h.Command[0], h.Command[1:]...)
+	cmd.Stdin = bytes.NewReader(payload)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("stopped after %s", timeout)
+		}
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// URL or command of a hook, for reports.
+func target(h config.HookConfig) string {
+	if h.URL != "" {
+		return h.URL
+	}
+	return h.Command[0]
+}
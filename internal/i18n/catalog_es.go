@@ -0,0 +1,246 @@
+package i18n
+
+var spanish = map[string]string{
+	// ---- weather codes ----
+	"Clear":                        "Despejado",
+	"Mostly clear":                 "Mayormente despejado",
+	"Partly cloudy":                "Parcialmente nublado",
+	"Overcast":                     "Cubierto",
+	"Fog":                          "Niebla",
+	"Icy fog":                      "Niebla helada",
+	"Light drizzle":                "Llovizna ligera",
+	"Drizzle":                      "Llovizna",
+	"Heavy drizzle":                "Llovizna intensa",
+	"Light freezing drizzle":       "Llovizna helada ligera",
+	"Heavy freezing drizzle":       "Llovizna helada intensa",
+	"Light rain":                   "Lluvia ligera",
+	"Rain":                         "Lluvia",
+	"Heavy rain":                   "Lluvia intensa",
+	"Light freezing rain":          "Lluvia helada ligera",
+	"Heavy freezing rain":          "Lluvia helada intensa",
+	"Light snowfall":               "Nevada ligera",
+	"Snowfall":                     "Nevada",
+	"Heavy snowfall":               "Nevada intensa",
+	"Snow grains":                  "Granos de nieve",
+	"Light rain showers":           "Chubascos ligeros",
+	"Rain showers":                 "Chubascos",
+	"Heavy rain showers":           "Chubascos intensos",
+	"Light snow showers":           "Chubascos de nieve ligeros",
+	"Heavy snow showers":           "Chubascos de nieve intensos",
+	"Thunderstorm":                 "Tormenta",
+	"Thunderstorm with light hail": "Tormenta con granizo ligero",
+	"Thunderstorm with heavy hail": "Tormenta con granizo intenso",
+
+	// ---- help ----
+	"new search":       "nueva búsqueda",
+	"recent locations": "lugares recientes",
+	"refresh":          "actualizar",
+	"compare models":   "comparar modelos",
+	"history":          "historial",
+	"next tab":         "siguiente pestaña",
+	"quit":             "salir",
+	"search":           "buscar",
+	"exit search":      "salir de la búsqueda",
+	"edit search":      "editar búsqueda",
+	"back":             "volver",
+	"up":               "subir",
+	"down":             "bajar",
+	"pick":             "elegir",
+	"back to forecast": "volver al pronóstico",
+	"earlier":          "anterior",
+	"later":            "siguiente",
+
+	// ---- search and recent ----
+	"Location search:":                  "Buscar lugar:",
+	"Finding location%s":                "Buscando lugar%s",
+	"Pick a location:":                  "Elige un lugar:",
+	"alias":                             "alias",
+	"delete":                            "borrar",
+	"move up":                           "mover arriba",
+	"move down":                         "mover abajo",
+	"clear all":                         "borrar todo",
+	"filter":                            "filtrar",
+	"save":                              "guardar",
+	"cancel":                            "cancelar",
+	"Alias for %s:":                     "Alias para %s:",
+	"Clear all recent locations?":       "¿Borrar todos los lugares recientes?",
+	"Failed to update recent locations": "No se pudieron actualizar los lugares recientes",
+	"Recent locations:":                 "Lugares recientes:",
+	"Lat: %.4f, Lon: %.4f":              "Lat: %.4f, Lon: %.4f",
+
+	// ---- errors ----
+	"retry":                           "reintentar",
+	"Failed to find locations":        "No se pudieron buscar lugares",
+	"Failed to load recent locations": "No se pudieron cargar los lugares recientes",
+	"Failed to get weather forecast":  "No se pudo obtener el pronóstico",
+	"Not compared, %v":                "Sin comparar, %v",
+	"Failed to compare forecasts":     "No se pudieron comparar los pronósticos",
+	"Failed to get weather history":   "No se pudo obtener el historial",
+
+	// ---- weather ----
+	"Loading forecast%s":                      "Cargando pronóstico%s",
+	"(via %s)":                                "(vía %s)",
+	"updating%s":                              "actualizando%s",
+	"refresh failed, showing older data":      "no se pudo actualizar, datos anteriores",
+	"updated just now":                        "actualizado ahora",
+	"updated %d min ago":                      "actualizado hace %d min",
+	"updated %d h ago":                        "actualizado hace %d h",
+	"no forecast available":                   "sin pronóstico disponible",
+	"Current location":                        "Ubicación actual",
+	"from GPS, IP or the configured position": "desde el GPS, la IP o la posición configurada",
+	"Finding current position%s":              "Obteniendo la posición actual%s",
+	"Failed to find current position":         "No se pudo obtener la posición actual",
+	"Forecast":                                "Pronóstico",
+	"Air quality":                             "Calidad del aire",
+	"Marine":                                  "Mar",
+	"Sun & moon":                              "Sol y luna",
+	"(feels like %.1f %s)":                    "(sensación de %.1f %s)",
+	"(feels like %s)":                         "(sensación de %s)",
+	"Min":                                     "Mín",
+	"Max":                                     "Máx",
+	"Wind":                                    "Viento",
+	"(force %d, %s)":                          "(fuerza %d, %s)",
+	"Wind gusts":                              "Ráfagas",
+	"Humidity":                                "Humedad",
+	"Dew point":                               "Punto de rocío",
+	"Heat index":                              "Índice de calor",
+	"Humidex":                                 "Humidex",
+	"Wind chill":                              "Sensación por viento",
+	"Comfort":                                 "Confort",
+	"Precipitation":                           "Precipitación",
+	"Pressure":                                "Presión",
+	"UV index":                                "Índice UV",
+	"Day":                                     "Día",
+	"Conditions":                              "Condiciones",
+	"Precip.":                                 "Precip.",
+	"UV":                                      "UV",
+	"Max vs normal":                           "Máx vs normal",
+	"%+.1f° above normal":                     "%+.1f° sobre lo normal",
+	"%+.1f° below normal":                     "%+.1f° bajo lo normal",
+	"%+.1f° record":                           "%+.1f° récord",
+	"normal":                                  "normal",
+	"record high":                             "máxima récord",
+	"record low":                              "mínima récord",
+	"! %s:":                                   "! %s:",
+
+	// ---- comfort ----
+	"Dangerously hot": "Calor peligroso",
+	"Hot":             "Caluroso",
+	"Freezing":        "Helado",
+	"Cold":            "Frío",
+	"Oppressive":      "Sofocante",
+	"Muggy":           "Bochornoso",
+	"Cool":            "Fresco",
+	"Humid":           "Húmedo",
+	"Dry":             "Seco",
+	"Comfortable":     "Agradable",
+
+	// ---- beaufort ----
+	"Calm":            "Calma",
+	"Light air":       "Ventolina",
+	"Light breeze":    "Brisa muy débil",
+	"Gentle breeze":   "Brisa débil",
+	"Moderate breeze": "Brisa moderada",
+	"Fresh breeze":    "Brisa fresca",
+	"Strong breeze":   "Brisa fuerte",
+	"Near gale":       "Viento fuerte",
+	"Gale":            "Temporal",
+	"Strong gale":     "Temporal fuerte",
+	"Storm":           "Temporal duro",
+	"Violent storm":   "Temporal muy duro",
+	"Hurricane force": "Huracán",
+
+	// ---- compass ----
+	"NNE": "NNE",
+	"NE":  "NE",
+	"ENE": "ENE",
+	"E":   "E",
+	"ESE": "ESE",
+	"SE":  "SE",
+	"SSE": "SSE",
+	"S":   "S",
+	"SSW": "SSO",
+	"SW":  "SO",
+	"WSW": "OSO",
+	"W":   "O",
+	"WNW": "ONO",
+	"NW":  "NO",
+	"NNW": "NNO",
+
+	// ---- air quality ----
+	"Loading air quality...":         "Cargando calidad del aire...",
+	"Failed to get air quality: %s":  "No se pudo obtener la calidad del aire: %s",
+	"European AQI":                   "ICA europeo",
+	"US AQI":                         "ICA EE. UU.",
+	"Ozone":                          "Ozono",
+	"Nitrogen dioxide":               "Dióxido de nitrógeno",
+	"Alder pollen":                   "Polen de aliso",
+	"Birch pollen":                   "Polen de abedul",
+	"Grass pollen":                   "Polen de gramíneas",
+	"Mugwort pollen":                 "Polen de artemisa",
+	"Olive pollen":                   "Polen de olivo",
+	"Ragweed pollen":                 "Polen de ambrosía",
+	"Good":                           "Buena",
+	"Fair":                           "Aceptable",
+	"Moderate":                       "Moderada",
+	"Poor":                           "Mala",
+	"Very poor":                      "Muy mala",
+	"Extremely poor":                 "Extremadamente mala",
+	"Unhealthy for sensitive groups": "Dañina para grupos sensibles",
+	"Unhealthy":                      "Dañina",
+	"Very unhealthy":                 "Muy dañina",
+	"Hazardous":                      "Peligrosa",
+
+	// ---- marine ----
+	"Waves":             "Olas",
+	"Wave period":       "Periodo de olas",
+	"Wave direction":    "Dirección de olas",
+	"Wind waves":        "Mar de viento",
+	"Swell":             "Mar de fondo",
+	"Swell period":      "Periodo del mar de fondo",
+	"Swell direction":   "Dirección del mar de fondo",
+	"Sea temperature":   "Temperatura del mar",
+	"Current":           "Corriente",
+	"Current direction": "Dirección de corriente",
+
+	// ---- sun and moon ----
+	"Now":              "Ahora",
+	"Night":            "Noche",
+	"%s, sun at %.1f°": "%s, sol a %.1f°",
+	"Nautical dawn":    "Alba náutica",
+	"Civil dawn":       "Alba civil",
+	"Sunrise":          "Amanecer",
+	"Solar noon":       "Mediodía solar",
+	"Sunset":           "Atardecer",
+	"Civil dusk":       "Ocaso civil",
+	"Nautical dusk":    "Ocaso náutico",
+	"Day length":       "Duración del día",
+	"Next":             "Próximo",
+	"%s in %s":         "%s en %s",
+	"Moon":             "Luna",
+	"%s, %.0f%% lit":   "%s, %.0f%% iluminada",
+	"(%.1f days old)":  "(%.1f días)",
+	"New moon":         "Luna nueva",
+	"Waxing crescent":  "Luna creciente",
+	"First quarter":    "Cuarto creciente",
+	"Waxing gibbous":   "Gibosa creciente",
+	"Full moon":        "Luna llena",
+	"Waning gibbous":   "Gibosa menguante",
+	"Last quarter":     "Cuarto menguante",
+	"Waning crescent":  "Luna menguante",
+
+	// ---- compare ----
+	"Comparing models%s": "Comparando modelos%s",
+	"Temperature":        "Temperatura",
+	"Feels like":         "Sensación",
+	"Cloud cover":        "Nubosidad",
+	"Spread":             "Diferencia",
+
+	// ---- history ----
+	"Loading history%s":  "Cargando historial%s",
+	"(history)":          "(historial)",
+	"Date":               "Fecha",
+	"Same day last year": "Mismo día del año pasado",
+	"Last year":          "Año pasado",
+	"Today (forecast)":   "Hoy (pronóstico)",
+}
@@ -0,0 +1,129 @@
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Language of the interface, as an ISO 639-1 code.
+// Also sent to the geocoding API so place names match.
+type Locale string
+
+const (
+	English Locale = "en"
+	Spanish Locale = "es"
+)
+
+// Translations by locale, keyed by the English message.
+// English needs no catalog, messages are written in it.
+var catalogs = map[Locale]map[string]string{
+	Spanish: spanish,
+}
+
+var current = English
+
+// Set the locale used by every translation from now on.
+// Called once at startup, before any view is built.
+func Set(locale Locale) {
+	current = locale
+}
+
+func Current() Locale {
+	return current
+}
+
+// Pick the locale from the config, or from the environment like gettext does.
+// Unsupported languages fall back to English.
+func Detect(configured string) Locale {
+	candidates := []string{configured, os.Getenv("LC_ALL"), os.Getenv("LC_MESSAGES"), os.Getenv("LANG")}
+	for _, candidate := range candidates {
+		if candidate == "" {
+			continue
+		}
+		return parse(candidate)
+	}
+	return English
+}
+
+// Language part of values like `es_EC.UTF-8` or `es-EC`.
+func parse(value string) Locale {
+	lang := strings.ToLower(value)
+	if i := strings.IndexAny(lang, "_-.@"); i >= 0 {
+		lang = lang[:i]
+	}
+	locale := Locale(lang)
+	if locale == English {
+		return English
+	}
+	if _, ok := catalogs[locale]; ok {
+		return locale
+	}
+	return English
+}
+
+// Translate a message, then format it with the arguments if there are any.
+// Surrounding whitespace is kept, so "\nMin" is looked up as "Min".
+// Messages without a translation are used as they are.
+func T(message string, args ...any) string {
+	text := strings.TrimSpace(message)
+	if translated, ok := catalogs[current][text]; ok {
+		start := strings.Index(message, text)
+		message = message[:start] + translated + message[start+len(text):]
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return Sprintf(message, args...)
+}
+
+// Like fmt.Sprintf, with floats written using the decimal separator of the locale.
+func Sprintf(format string, args ...any) string {
+	if current == English {
+		return fmt.Sprintf(format, args...)
+	}
+	localized := make([]any, len(args))
+	for i, arg := range args {
+		if f, ok := arg.(float64); ok {
+			localized[i] = localFloat(f)
+		} else {
+			localized[i] = arg
+		}
+	}
+	return fmt.Sprintf(format, localized...)
+}
+
+// Float that swaps the decimal point for a comma when formatted.
+type localFloat float64
+
+func (f localFloat) Format(s fmt.State, verb rune) {
+	formatted := fmt.Sprintf(fmt.FormatString(s, verb), float64(f))
+	fmt.Fprint(s, strings.Replace(formatted, ".", ",", 1))
+}
+
+var weekdays = map[Locale][7]string{
+	Spanish: {"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+}
+
+var months = map[Locale][12]string{
+	Spanish: {"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+}
+
+// Weekday and day of the month, e.g. "Mon 02" or "lun 02".
+func Day(t time.Time) string {
+	names, ok := weekdays[current]
+	if !ok {
+		return t.Format("Mon 02")
+	}
+	return fmt.Sprintf("%s %02d", names[t.Weekday()], t.Day())
+}
+
+// Full date, e.g. "Mon, Oct 18 2026" or "lun, 18 oct 2026".
+func Date(t time.Time) string {
+	days, ok := weekdays[current]
+	if !ok {
+		return t.Format("Mon, Jan 2 2006")
+	}
+	return fmt.Sprintf("%s, %d %s %d", days[t.Weekday()], t.Day(), months[current][t.Month()-1], t.Year())
+}
@@ -0,0 +1,119 @@
+package location
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/oschwald/maxminddb-golang"
+
+	"github.com/esferadigital/clima/internal/i18n"
+	"github.com/esferadigital/clima/internal/openmeteo"
+)
+
+// Time given to the endpoint that reports the public IP.
+const IP_ENDPOINT_TIMEOUT = 5 * time.Second
+
+// Position of the public IP in a local GeoLite2-City or DB-IP City database.
+// The IP is either given or asked to an endpoint answering with it as plain text.
+// Accuracy is a city at best, enough for a forecast.
+type IPDatabase struct {
+	// Path to the .mmdb file.
+	Path string
+	// Public IP, empty to ask the endpoint.
+	IP string
+	// URL answering with the public IP, e.g. a service on the router.
+	Endpoint string
+}
+
+// Fields of a city database record, as GeoLite2 and DB-IP name them.
+type cityRecord struct {
+	City struct {
+		Names map[string]string `maxminddb:"names"`
+	} `maxminddb:"city"`
+	Country struct {
+		Names map[string]string `maxminddb:"names"`
+	} `maxminddb:"country"`
+	Subdivisions []struct {
+		Names map[string]string `maxminddb:"names"`
+	} `maxminddb:"subdivisions"`
+	Location struct {
+		Latitude  *float64 `maxminddb:"latitude"`
+		Longitude *float64 `maxminddb:"longitude"`
+	} `maxminddb:"location"`
+}
+
+func (d IPDatabase) Name() string {
+	return "ip database"
+}
+
+func (d IPDatabase) Locate() (openmeteo.GeocodingResult, error) {
+	ip, err := d.publicIP()
+	if err != nil {
+		return openmeteo.GeocodingResult{}, err
+	}
+
+	db, err := maxminddb.Open(d.Path)
+	if err != nil {
+		return openmeteo.GeocodingResult{}, err
+	}
+	defer db.Close()
+
+	var record cityRecord
+	_, found, err := db.LookupNetwork(ip, &record)
+	if err != nil {
+		return openmeteo.GeocodingResult{}, err
+	}
+	if !found || record.Location.Latitude == nil || record.Location.Longitude == nil {
+		return openmeteo.GeocodingResult{}, fmt.Errorf("no position for %s", ip)
+	}
+
+	loc := AtCoordinates(*record.Location.Latitude, *record.Location.Longitude)
+	if city := localName(record.City.Names); city != "" {
+		loc.Name = city
+		loc.Country = localName(record.Country.Names)
+		if len(record.Subdivisions) > 0 {
+			loc.Admin1 = localName(record.Subdivisions[0].Names)
+		}
+	}
+	return loc, nil
+}
+
+// The IP that was given, or the one the endpoint answers with.
+func (d IPDatabase) publicIP() (net.IP, error) {
+	text := d.IP
+	if text == "" {
+		client := http.Client{Timeout: IP_ENDPOINT_TIMEOUT}
+		res, err := client.Get(d.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+		defer res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("ip endpoint answered %s", res.Status)
+		}
+		// An address is short, anything longer is not one
+		body, err := io.ReadAll(io.LimitReader(res.Body, 64))
+		if err != nil {
+			return nil, err
+		}
+		text = string(body)
+	}
+
+	ip := net.ParseIP(strings.TrimSpace(text))
+	if ip == nil {
+		return nil, fmt.Errorf("%q is not an IP address", strings.TrimSpace(text))
+	}
+	return ip, nil
+}
+
+// Name in the interface language, English otherwise.
+func localName(names map[string]string) string {
+	if name, ok := names[string(i18n.Current())]; ok {
+		return name
+	}
+	return names["en"]
+}
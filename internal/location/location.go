@@ -0,0 +1,108 @@
+package location
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/esferadigital/clima/internal/i18n"
+	"github.com/esferadigital/clima/internal/openmeteo"
+	"github.com/esferadigital/clima/internal/store"
+)
+
+// Find a location by name or coordinates for non-interactive use.
+// Coordinates are used as they are. Recent locations are checked next, by alias or name,
+// so the same place the TUI used is picked, then the best geocoding match is used.
+func Resolve(query string) (openmeteo.GeocodingResult, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return openmeteo.GeocodingResult{}, fmt.Errorf("empty location")
+	}
+	if loc, ok := ParseCoordinates(query); ok {
+		return loc, nil
+	}
+
+	recent, err := store.LoadRecentLocations()
+	if err != nil {
+		return openmeteo.GeocodingResult{}, err
+	}
+	for _, loc := range recent {
+		if strings.EqualFold(loc.Alias, query) || strings.EqualFold(loc.Name, query) {
+			return loc.GeocodingResult, nil
+		}
+	}
+
+	res, err := openmeteo.SearchLocation(openmeteo.GeocodingParams{
+		Name:     query,
+		Count:    1,
+		Language: string(i18n.Current()),
+	})
+	if err != nil {
+		return openmeteo.GeocodingResult{}, err
+	}
+	if len(res.Results) == 0 {
+		return openmeteo.GeocodingResult{}, fmt.Errorf("no location found for %q", query)
+	}
+
+	return res.Results[0], nil
+}
+
+// Whether a query, as given to Resolve, names the location:
+// by its coordinates, its name or label, or the alias of its recent entry.
+func Matches(query string, loc openmeteo.GeocodingResult) bool {
+	query = strings.TrimSpace(query)
+	if at, ok := ParseCoordinates(query); ok {
+		// About a kilometer, coordinates typed by hand are rounded
+		return math.Abs(at.Latitude-loc.Latitude) < 0.01 && math.Abs(at.Longitude-loc.Longitude) < 0.01
+	}
+	if strings.EqualFold(query, loc.Name) || strings.EqualFold(query, loc.Label()) {
+		return true
+	}
+
+	recent, err := store.LoadRecentLocations()
+	if err != nil {
+		return false
+	}
+	for _, r := range recent {
+		if r.ID == loc.ID && r.Alias != "" && strings.EqualFold(r.Alias, query) {
+			return true
+		}
+	}
+	return false
+}
+
+// Location for coordinates written as "lat,lon", e.g. "-0.2299,-78.5249",
+// or as a geo URI, e.g. "geo:-0.2299,-78.5249;u=35". Altitude is ignored.
+func ParseCoordinates(s string) (openmeteo.GeocodingResult, bool) {
+	if scheme, rest, ok := strings.Cut(s, ":"); ok && strings.EqualFold(scheme, "geo") {
+		s, _, _ = strings.Cut(rest, ";")
+	}
+	parts := strings.Split(s, ",")
+	if len(parts) < 2 || len(parts) > 3 {
+		return openmeteo.GeocodingResult{}, false
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return openmeteo.GeocodingResult{}, false
+	}
+	long, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil || long < -180 || long > 180 {
+		return openmeteo.GeocodingResult{}, false
+	}
+	return AtCoordinates(lat, long), true
+}
+
+// Location without a place name, named after its coordinates.
+// The id is negative so it never matches a geocoding id, and the same
+// coordinates always get the same one, so they share a recent entry.
+func AtCoordinates(lat float64, long float64) openmeteo.GeocodingResult {
+	row := int(math.Round((lat + 90) * 1e4))
+	col := int(math.Round((long + 180) * 1e4))
+	return openmeteo.GeocodingResult{
+		ID:        -(row*3_600_001 + col + 1),
+		Name:      fmt.Sprintf("%.4f, %.4f", lat, long),
+		Latitude:  lat,
+		Longitude: long,
+	}
+}
@@ -0,0 +1,144 @@
+package location
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/esferadigital/clima/internal/config"
+	"github.com/esferadigital/clima/internal/openmeteo"
+)
+
+// Somewhere the current position of the machine can be read from.
+type Source interface {
+	// Short name for errors, e.g. "gpsd".
+	Name() string
+	// Current position, named after its coordinates.
+	Locate() (openmeteo.GeocodingResult, error)
+}
+
+// Ordered list of sources. The first one with a position wins.
+type Sources []Source
+
+// Build the sources from the user config, in the order they are tried:
+// gpsd first, then the IP database, then the static position as a fallback.
+func SourcesFromConfig(cfg config.Config) (Sources, error) {
+	var sources Sources
+	if cfg.Position.Gpsd != "" {
+		sources = append(sources, Gpsd{Address: cfg.Position.Gpsd, Timeout: GPSD_TIMEOUT})
+	}
+	if cfg.Position.IPDatabase != "" {
+		if cfg.Position.IP == "" && cfg.Position.IPEndpoint == "" {
+			return nil, errors.New("ip database needs an ip or an ip endpoint")
+		}
+		if cfg.Position.IP != "" && net.ParseIP(cfg.Position.IP) == nil {
+			return nil, fmt.Errorf("%q is not an IP address", cfg.Position.IP)
+		}
+		sources = append(sources, IPDatabase{Path: cfg.Position.IPDatabase, IP: cfg.Position.IP, Endpoint: cfg.Position.IPEndpoint})
+	}
+	if cfg.Position.Static != "" {
+		loc, ok := ParseCoordinates(cfg.Position.Static)
+		if !ok {
+			return nil, fmt.Errorf("static position %q is not \"lat,lon\" nor a geo URI", cfg.Position.Static)
+		}
+		sources = append(sources, Static{Location: loc})
+	}
+	return sources, nil
+}
+
+// Position from the first source that has one, or the errors of all of them.
+func (s Sources) Locate() (openmeteo.GeocodingResult, error) {
+	if len(s) == 0 {
+		return openmeteo.GeocodingResult{}, errors.New("no position sources configured")
+	}
+	var errs []error
+	for _, source := range s {
+		loc, err := source.Locate()
+		if err == nil {
+			return loc, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", source.Name(), err))
+	}
+	return openmeteo.GeocodingResult{}, errors.Join(errs...)
+}
+
+// ---- static ----
+
+// Fixed position from the config, for machines without a receiver.
+type Static struct {
+	Location openmeteo.GeocodingResult
+}
+
+func (s Static) Name() string {
+	return "static"
+}
+
+func (s Static) Locate() (openmeteo.GeocodingResult, error) {
+	return s.Location, nil
+}
+
+// ---- gpsd ----
+
+// Time given to gpsd to report a fix. Receivers send one every second or so.
+const GPSD_TIMEOUT = 5 * time.Second
+
+// GPS daemon reached over its JSON protocol on TCP, usually at localhost:2947.
+// https://gpsd.gitlab.io/gpsd/gpsd_json.html
+type Gpsd struct {
+	Address string
+	Timeout time.Duration
+}
+
+// Report from gpsd. Only time-position-velocity reports are used.
+type gpsdReport struct {
+	Class string  `json:"class"`
+	Mode  int     `json:"mode"`
+	Lat   float64 `json:"lat"`
+	Lon   float64 `json:"lon"`
+}
+
+// Modes of a TPV report. 2D is enough for a forecast.
+const GPSD_MODE_2D = 2
+
+func (g Gpsd) Name() string {
+	return "gpsd"
+}
+
+// Ask gpsd to stream reports and wait for the first one with a fix.
+func (g Gpsd) Locate() (openmeteo.GeocodingResult, error) {
+	conn, err := net.DialTimeout("tcp", g.Address, g.Timeout)
+	if err != nil {
+		return openmeteo.GeocodingResult{}, err
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(g.Timeout)); err != nil {
+		return openmeteo.GeocodingResult{}, err
+	}
+	if _, err := fmt.Fprint(conn, `?WATCH={"enable":true,"json":true};`+"\n"); err != nil {
+		return openmeteo.GeocodingResult{}, err
+	}
+
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		var report gpsdReport
+		// Lines that are not reports are skipped
+		if err := json.Unmarshal(scanner.Bytes(), &report); err != nil {
+			continue
+		}
+		if report.Class == "TPV" && report.Mode >= GPSD_MODE_2D {
+			return AtCoordinates(report.Lat, report.Lon), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return openmeteo.GeocodingResult{}, fmt.Errorf("no fix within %s", g.Timeout)
+		}
+		return openmeteo.GeocodingResult{}, err
+	}
+	return openmeteo.GeocodingResult{}, errors.New("connection closed before a fix")
+}
@@ -0,0 +1,125 @@
+package location
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+const gpsdVersion = `{"class":"VERSION","release":"3.25","rev":"3.25","proto_major":3,"proto_minor":15}`
+
+// Fake gpsd that waits for the WATCH command, sends the lines and,
+// unless hold is set, closes the connection.
+func fakeGpsd(t *testing.T, lines []string, hold bool) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	done := make(chan struct{})
+	t.Cleanup(func() { close(done) })
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		watch, err := bufio.NewReader(conn).ReadString('\n')
+		if err != nil || !strings.HasPrefix(watch, "?WATCH=") {
+			t.Errorf("expected a WATCH command, got %q (%v)", watch, err)
+			return
+		}
+		for _, line := range lines {
+			if _, err := conn.Write([]byte(line + "\r\n")); err != nil {
+				return
+			}
+		}
+		if hold {
+			<-done
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func TestGpsdLocate(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		hold  bool
+		lat   float64
+		lon   float64
+		err   string
+	}{
+		{
+			name: "2D fix",
+			lines: []string{
+				gpsdVersion,
+				`{"class":"DEVICES","devices":[]}`,
+				`{"class":"WATCH","enable":true,"json":true}`,
+				`{"class":"TPV","mode":2,"lat":-0.2201,"lon":-78.5123}`,
+			},
+			lat: -0.2201,
+			lon: -78.5123,
+		},
+		{
+			name: "3D fix after reports without one",
+			lines: []string{
+				gpsdVersion,
+				`{"class":"TPV","mode":1}`,
+				`{"class":"SKY","satellites":[]}`,
+				`not json`,
+				`{"class":"TPV","mode":3,"lat":40.4168,"lon":-3.7038,"alt":657.0}`,
+			},
+			lat: 40.4168,
+			lon: -3.7038,
+		},
+		{
+			name:  "no fix before the timeout",
+			lines: []string{gpsdVersion, `{"class":"TPV","mode":1}`},
+			hold:  true,
+			err:   "no fix within",
+		},
+		{
+			name:  "server closes early",
+			lines: []string{gpsdVersion, `{"class":"TPV","mode":0}`},
+			err:   "connection closed before a fix",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gpsd := Gpsd{Address: fakeGpsd(t, tt.lines, tt.hold), Timeout: 200 * time.Millisecond}
+			loc, err := gpsd.Locate()
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("Locate() error = %v, want one containing %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Locate() error = %v", err)
+			}
+			if loc.Latitude != tt.lat || loc.Longitude != tt.lon {
+				t.Fatalf("Locate() = %f, %f, want %f, %f", loc.Latitude, loc.Longitude, tt.lat, tt.lon)
+			}
+		})
+	}
+}
+
+func TestGpsdUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := listener.Addr().String()
+	listener.Close()
+
+	if _, err := (Gpsd{Address: address, Timeout: 200 * time.Millisecond}).Locate(); err == nil {
+		t.Fatal("Locate() on a closed port succeeded")
+	}
+}
@@ -0,0 +1,106 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics of clima in the Prometheus and OpenMetrics formats.
+// Conditions are in metric units whatever the config says, as Prometheus expects.
+var registry = prometheus.NewRegistry()
+
+// ---- client ----
+
+var (
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "clima_openmeteo_request_duration_seconds",
+		Help:    "Time taken by requests to the Open-Meteo APIs, by endpoint.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"endpoint"})
+	requestErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "clima_openmeteo_request_errors_total",
+		Help: "Failed requests to the Open-Meteo APIs, by endpoint.",
+	}, []string{"endpoint"})
+	cacheLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "clima_forecast_cache_lookups_total",
+		Help: "Forecasts looked up in the cache, by result: hit or miss.",
+	}, []string{"result"})
+)
+
+// ---- conditions ----
+
+var (
+	up        = gauge("clima_location_up", "Whether the last update of the location succeeded.")
+	fetchedAt = gauge("clima_forecast_fetched_timestamp_seconds", "When the conditions of the location were fetched.")
+	// Gauges by the current weather variable they show.
+	conditions = map[string]*prometheus.GaugeVec{
+		"temperature_2m":       gauge("clima_temperature_celsius", "Air temperature at 2 m."),
+		"apparent_temperature": gauge("clima_apparent_temperature_celsius", "Temperature felt, from wind chill and humidity."),
+		"relative_humidity_2m": gauge("clima_relative_humidity_percent", "Relative humidity at 2 m."),
+		"weather_code":         gauge("clima_weather_code", "WMO weather interpretation code."),
+		"wind_speed_10m":       gauge("clima_wind_speed_meters_per_second", "Wind speed at 10 m."),
+		"wind_gusts_10m":       gauge("clima_wind_gusts_meters_per_second", "Wind gusts at 10 m."),
+		"wind_direction_10m":   gauge("clima_wind_direction_degrees", "Direction the wind blows from at 10 m."),
+		"precipitation":        gauge("clima_precipitation_millimeters", "Precipitation of the preceding hour."),
+		"pressure_msl":         gauge("clima_pressure_hectopascals", "Air pressure reduced to sea level."),
+	}
+)
+
+func gauge(name string, help string) *prometheus.GaugeVec {
+	return prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: name, Help: help}, []string{"location"})
+}
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		requestDuration, requestErrors, cacheLookups, up, fetchedAt,
+	)
+	for _, g := range conditions {
+		registry.MustRegister(g)
+	}
+}
+
+// Serves the metrics, in OpenMetrics when the scraper asks for it.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{EnableOpenMetrics: true})
+}
+
+// Record a request to an Open-Meteo endpoint, e.g. "api.open-meteo.com/v1/forecast".
+func ObserveRequest(endpoint string, elapsed time.Duration, err error) {
+	requestDuration.WithLabelValues(endpoint).Observe(elapsed.Seconds())
+	if err != nil {
+		requestErrors.WithLabelValues(endpoint).Inc()
+	}
+}
+
+// Record whether a fresh forecast was found in the cache.
+func ObserveCache(hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	cacheLookups.WithLabelValues(result).Inc()
+}
+
+// Show the current conditions of a location. Variables missing from them are left out.
+func SetConditions(location string, at time.Time, current map[string]any) {
+	up.WithLabelValues(location).Set(1)
+	fetchedAt.WithLabelValues(location).Set(float64(at.Unix()))
+	for variable, g := range conditions {
+		if value, ok := current[variable].(float64); ok {
+			g.WithLabelValues(location).Set(value)
+		} else {
+			g.DeleteLabelValues(location)
+		}
+	}
+}
+
+// Mark the update of a location as failed. The last conditions are kept.
+func SetFailed(location string) {
+	up.WithLabelValues(location).Set(0)
+}
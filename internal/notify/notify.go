@@ -0,0 +1,108 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+
+	"github.com/esferadigital/clima/internal/alert"
+	"github.com/esferadigital/clima/internal/config"
+)
+
+// Somewhere alerts are delivered.
+type Notifier interface {
+	Notify(a alert.Alert) error
+}
+
+// The command hook of the config if there is one, desktop notifications otherwise.
+func FromConfig(cfg config.Config) Notifier {
+	if len(cfg.AlertCommand) > 0 {
+		return Command{Args: cfg.AlertCommand, Timeout: COMMAND_TIMEOUT}
+	}
+	return Desktop{}
+}
+
+// ---- desktop ----
+
+// Desktop notification through the org.freedesktop.Notifications service on the session bus.
+// https://specifications.freedesktop.org/notification-spec/latest/
+type Desktop struct{}
+
+const (
+	NOTIFICATIONS_NAME   = "org.freedesktop.Notifications"
+	NOTIFICATIONS_PATH   = "/org/freedesktop/Notifications"
+	NOTIFICATIONS_METHOD = NOTIFICATIONS_NAME + ".Notify"
+	// Urgency hint of the spec, 1 is normal
+	URGENCY_NORMAL byte = 1
+)
+
+func (d Desktop) Notify(a alert.Alert) error {
+	// The session connection is shared, so it is not closed
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return err
+	}
+
+	summary := fmt.Sprintf("%s: %s", a.Rule.Name, a.Location.Label())
+	hints := map[string]dbus.Variant{"urgency": dbus.MakeVariant(URGENCY_NORMAL)}
+	call := conn.Object(NOTIFICATIONS_NAME, NOTIFICATIONS_PATH).Call(
+		NOTIFICATIONS_METHOD, 0,
+		"clima",     // app name
+		uint32(0),   // id to replace, none
+		"",          // icon
+		summary,     // summary
+		a.Details(), // body
+		[]string{},  // actions
+		hints,       // hints
+		int32(-1),   // expiration, the server default
+	)
+	return call.Err
+}
+
+// ---- dry run ----
+
+// Sends nothing, alerts are only printed by the caller.
+type DryRun struct{}
+
+func (d DryRun) Notify(a alert.Alert) error {
+	return nil
+}
+
+// ---- command ----
+
+// Time a command hook may run before it is stopped.
+const COMMAND_TIMEOUT = 10 * time.Second
+
+// User command run for each alert. The alert is passed in the environment:
+// CLIMA_ALERT_NAME, CLIMA_ALERT_LOCATION, CLIMA_ALERT_LATITUDE,
+// CLIMA_ALERT_LONGITUDE and CLIMA_ALERT_DETAILS.
+type Command struct {
+	Args    []string
+	Timeout time.Duration
+}
+
+func (c Command) Notify(a alert.Alert) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, c.Args[0], c.Args[1:]...)
+	cmd.Env = append(os.Environ(),
+		"CLIMA_ALERT_NAME="+a.Rule.Name,
+		"CLIMA_ALERT_LOCATION="+a.Location.Label(),
+		fmt.Sprintf("CLIMA_ALERT_LATITUDE=%f", a.Location.Latitude),
+		fmt.Sprintf("CLIMA_ALERT_LONGITUDE=%f", a.Location.Longitude),
+		"CLIMA_ALERT_DETAILS="+a.Details(),
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("%s: stopped after %s", c.Args[0], c.Timeout)
+		}
+		return fmt.Errorf("%s: %w: %s", c.Args[0], err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package openmeteo
+
+import (
+	"fmt"
+)
+
+// Parameters for the Open-Meteo Air Quality V1 API.
+// These are not exclusive. Check the docs for additional ones.
+// https://open-meteo.com/en/docs/air-quality-api
+type AirQualityParams struct {
+	Latitude  float64
+	Longitude float64
+	Current   []AirQualityVariables
+}
+
+// Response from the Open-Meteo Air Quality V1 API.
+// Pollen variables are only available in Europe during the pollen season,
+// elsewhere they come back as `nil`.
+type AirQualityResponse struct {
+	Latitude         float64        `json:"latitude"`
+	Longitude        float64        `json:"longitude"`
+	Elevation        float64        `json:"elevation"`
+	GenerationTimeMs float64        `json:"generation_time_ms"`
+	UTCOffsetSeconds int            `json:"utc_offset_seconds"`
+	Timezone         string         `json:"timezone"`
+	TimezoneAbbrev   string         `json:"timezone_abbreviation"`
+	CurrentUnits     map[string]any `json:"current_units"`
+	Current          map[string]any `json:"current"`
+}
+
+// Variables available to request from the Open-Meteo Air Quality V1 API.
+type AirQualityVariables string
+
+const (
+	PM10            AirQualityVariables = "pm10"
+	PM2_5           AirQualityVariables = "pm2_5"
+	CarbonMonoxide  AirQualityVariables = "carbon_monoxide"
+	NitrogenDioxide AirQualityVariables = "nitrogen_dioxide"
+	SulphurDioxide  AirQualityVariables = "sulphur_dioxide"
+	Ozone           AirQualityVariables = "ozone"
+	Dust            AirQualityVariables = "dust"
+	EuropeanAQI     AirQualityVariables = "european_aqi"
+	USAQI           AirQualityVariables = "us_aqi"
+	AlderPollen     AirQualityVariables = "alder_pollen"
+	BirchPollen     AirQualityVariables = "birch_pollen"
+	GrassPollen     AirQualityVariables = "grass_pollen"
+	MugwortPollen   AirQualityVariables = "mugwort_pollen"
+	OlivePollen     AirQualityVariables = "olive_pollen"
+	RagweedPollen   AirQualityVariables = "ragweed_pollen"
+)
+
+const AIR_QUALITY_API_URL = "https://air-quality-api.open-meteo.com/v1/air-quality"
+
+// Retrieve the current air quality for a given location and parameters.
+// Data is provided by the Open-Meteo API.
+func GetAirQuality(params AirQualityParams) (AirQualityResponse, error) {
+	url := fmt.Sprintf("%s?latitude=%f&longitude=%f", AIR_QUALITY_API_URL, params.Latitude, params.Longitude)
+	if len(params.Current) > 0 {
+		currentVars := writeVariableCSV(params.Current)
+		url += fmt.Sprintf("&current=%s", currentVars)
+	}
+
+	var response AirQualityResponse
+	if err := getJSON(url, &response); err != nil {
+		return AirQualityResponse{}, err
+	}
+
+	return response, nil
+}
+
+// Air quality index band.
+// Level goes from 0 (best) to 5 (worst) on both scales so they can share colors.
+type AQICategory struct {
+	Level int
+	Name  string
+}
+
+// Categorize a European AQI value.
+// https://ecmwf-projects.github.io/copernicus-training-cams/proc-aq-index.html
+func EuropeanAQICategory(aqi float64) AQICategory {
+	switch {
+	case aqi <= 20:
+		return AQICategory{0, "Good"}
+	case aqi <= 40:
+		return AQICategory{1, "Fair"}
+	case aqi <= 60:
+		return AQICategory{2, "Moderate"}
+	case aqi <= 80:
+		return AQICategory{3, "Poor"}
+	case aqi <= 100:
+		return AQICategory{4, "Very poor"}
+	default:
+		return AQICategory{5, "Extremely poor"}
+	}
+}
+
+// Categorize a United States AQI value.
+// https://www.airnow.gov/aqi/aqi-basics/
+func USAQICategory(aqi float64) AQICategory {
+	switch {
+	case aqi <= 50:
+		return AQICategory{0, "Good"}
+	case aqi <= 100:
+		return AQICategory{1, "Moderate"}
+	case aqi <= 150:
+		return AQICategory{2, "Unhealthy for sensitive groups"}
+	case aqi <= 200:
+		return AQICategory{3, "Unhealthy"}
+	case aqi <= 300:
+		return AQICategory{4, "Very unhealthy"}
+	default:
+		return AQICategory{5, "Hazardous"}
+	}
+}
@@ -0,0 +1,64 @@
+package openmeteo
+
+import (
+	"fmt"
+	"time"
+)
+
+// Parameters for the Open-Meteo Historical Weather V1 API.
+// These are not exclusive. Check the docs for additional ones.
+// https://open-meteo.com/en/docs/historical-weather-api
+type ArchiveParams struct {
+	Latitude  float64
+	Longitude float64
+	StartDate time.Time
+	EndDate   time.Time
+	Daily     []DailyWeatherVariables
+	Hourly    []HourlyWeatherVariables
+	Units     Units
+}
+
+// Response from the Open-Meteo Historical Weather V1 API.
+// Each entry of `Daily` and `Hourly` is an array aligned with its `time` entry.
+type ArchiveResponse struct {
+	Latitude         float64        `json:"latitude"`
+	Longitude        float64        `json:"longitude"`
+	Elevation        float64        `json:"elevation"`
+	GenerationTimeMs float64        `json:"generation_time_ms"`
+	UTCOffsetSeconds int            `json:"utc_offset_seconds"`
+	Timezone         string         `json:"timezone"`
+	TimezoneAbbrev   string         `json:"timezone_abbreviation"`
+	HourlyUnits      map[string]any `json:"hourly_units"`
+	Hourly           map[string]any `json:"hourly"`
+	DailyUnits       map[string]any `json:"daily_units"`
+	Daily            map[string]any `json:"daily"`
+}
+
+const ARCHIVE_API_URL = "https://archive-api.open-meteo.com/v1/archive"
+
+// Retrieve past weather for a given location and date range.
+// Recent days may be missing, the archive lags a few days behind.
+// Data is provided by the Open-Meteo API.
+func GetArchive(params ArchiveParams) (ArchiveResponse, error) {
+	url := fmt.Sprintf(
+		"%s?latitude=%f&longitude=%f&start_date=%s&end_date=%s&timezone=auto",
+		ARCHIVE_API_URL, params.Latitude, params.Longitude,
+		params.StartDate.Format(time.DateOnly), params.EndDate.Format(time.DateOnly),
+	)
+	if len(params.Daily) > 0 {
+		dailyVars := writeVariableCSV(params.Daily)
+		url += fmt.Sprintf("&daily=%s", dailyVars)
+	}
+	if len(params.Hourly) > 0 {
+		hourlyVars := writeVariableCSV(params.Hourly)
+		url += fmt.Sprintf("&hourly=%s", hourlyVars)
+	}
+	url += params.Units.query()
+
+	var response ArchiveResponse
+	if err := getJSON(url, &response); err != nil {
+		return ArchiveResponse{}, err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,53 @@
+package openmeteo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"time"
+
+	"github.com/esferadigital/clima/internal/metrics"
+)
+
+// Body of a failed request to any of the Open-Meteo APIs.
+type errorResponse struct {
+	Error  bool   `json:"error"`
+	Reason string `json:"reason"`
+}
+
+// Send a GET request and decode the JSON response into `v`.
+// The reason reported by the API is included in the error when available.
+// Its duration and result are recorded in the metrics of the endpoint.
+func getJSON(url string, v any) (err error) {
+	start := time.Now()
+	defer func() {
+		metrics.ObserveRequest(endpoint(url), time.Since(start), err)
+	}()
+
+	resp, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	decoder := json.NewDecoder(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		var apiErr errorResponse
+		if err := decoder.Decode(&apiErr); err == nil && apiErr.Reason != "" {
+			return fmt.Errorf("unexpected status code: %d: %s", resp.StatusCode, apiErr.Reason)
+		}
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	return decoder.Decode(v)
+}
+
+// Host and path of a request URL, without the query, to label its metrics.
+func endpoint(url string) string {
+	u, err := neturl.Parse(url)
+	if err != nil {
+		return "unknown"
+	}
+	return u.Host + u.Path
+}
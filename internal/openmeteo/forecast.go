@@ -1,10 +1,9 @@
 package openmeteo
 
 import (
-	"encoding/json"
 	"fmt"
-	"net/http"
 	"strings"
+	"time"
 )
 
 // Parameters for the Open-Meteo Forecast V1 API.
@@ -14,7 +13,13 @@ type ForecastParams struct {
 	Latitude  float64
 	Longitude float64
 	Current   []CurrentWeatherVariables
+	Hourly    []HourlyWeatherVariables
 	Daily     []DailyWeatherVariables
+	Models    []WeatherModel
+	Units     Units
+	// IANA name or `auto` to use the timezone of the location.
+	// Daily values and timestamps are in GMT when empty.
+	Timezone string
 }
 
 // Response from the Open-Meteo Forecast V1 API.
@@ -31,6 +36,8 @@ type ForecastResponse struct {
 	TimezoneAbbrev   string         `json:"timezone_abbreviation"`
 	CurrentUnits     map[string]any `json:"current_units"`
 	Current          map[string]any `json:"current"`
+	HourlyUnits      map[string]any `json:"hourly_units"`
+	Hourly           map[string]any `json:"hourly"`
 	DailyUnits       map[string]any `json:"daily_units"`
 	Daily            map[string]any `json:"daily"`
 }
@@ -60,9 +67,64 @@ const (
 type DailyWeatherVariables string
 
 const (
-	Temperature2mMin DailyWeatherVariables = "temperature_2m_min"
-	Temperature2mMax DailyWeatherVariables = "temperature_2m_max"
-	UVIndexMax       DailyWeatherVariables = "uv_index_max"
+	DailyWeatherCode  DailyWeatherVariables = "weather_code"
+	Temperature2mMin  DailyWeatherVariables = "temperature_2m_min"
+	Temperature2mMax  DailyWeatherVariables = "temperature_2m_max"
+	Temperature2mMean DailyWeatherVariables = "temperature_2m_mean"
+	PrecipitationSum  DailyWeatherVariables = "precipitation_sum"
+	WindSpeed10mMax   DailyWeatherVariables = "wind_speed_10m_max"
+	UVIndexMax        DailyWeatherVariables = "uv_index_max"
+	Sunrise           DailyWeatherVariables = "sunrise"
+	Sunset            DailyWeatherVariables = "sunset"
+	DaylightDuration  DailyWeatherVariables = "daylight_duration"
+)
+
+// Variables available to request from the Open-Meteo Forecast V1 and Historical Weather V1 APIs for hourly weather.
+type HourlyWeatherVariables string
+
+const (
+	HourlyTemperature2m       HourlyWeatherVariables = "temperature_2m"
+	HourlyRelativeHumidity2m  HourlyWeatherVariables = "relative_humidity_2m"
+	HourlyPrecipitation       HourlyWeatherVariables = "precipitation"
+	HourlyWeatherCode         HourlyWeatherVariables = "weather_code"
+	HourlyWindSpeed10m        HourlyWeatherVariables = "wind_speed_10m"
+	HourlyWindGusts10m        HourlyWeatherVariables = "wind_gusts_10m"
+	HourlyApparentTemperature HourlyWeatherVariables = "apparent_temperature"
+	HourlySnowfall            HourlyWeatherVariables = "snowfall"
+	HourlyCloudCover          HourlyWeatherVariables = "cloud_cover"
+	// Only available from the Forecast V1 API.
+	HourlyPrecipitationProbability HourlyWeatherVariables = "precipitation_probability"
+	HourlyUVIndex                  HourlyWeatherVariables = "uv_index"
+)
+
+// Every hourly variable above, e.g. to check names written by users.
+var HourlyVariables = []HourlyWeatherVariables{
+	HourlyTemperature2m,
+	HourlyRelativeHumidity2m,
+	HourlyPrecipitation,
+	HourlyWeatherCode,
+	HourlyWindSpeed10m,
+	HourlyWindGusts10m,
+	HourlyApparentTemperature,
+	HourlySnowfall,
+	HourlyCloudCover,
+	HourlyPrecipitationProbability,
+	HourlyUVIndex,
+}
+
+// Weather models available from the Open-Meteo Forecast V1 API.
+// When none is requested, the API blends the best models for the location.
+type WeatherModel string
+
+const (
+	ModelBestMatch   WeatherModel = "best_match"
+	ModelECMWF       WeatherModel = "ecmwf_ifs025"
+	ModelGFS         WeatherModel = "gfs_seamless"
+	ModelICON        WeatherModel = "icon_seamless"
+	ModelGEM         WeatherModel = "gem_seamless"
+	ModelMeteoFrance WeatherModel = "meteofrance_seamless"
+	ModelJMA         WeatherModel = "jma_seamless"
+	ModelUKMO        WeatherModel = "ukmo_seamless"
 )
 
 // Compose a comma-separated string of variable names.
@@ -80,34 +142,127 @@ const FORECAST_API_URL = "https://api.open-meteo.com/v1/forecast"
 // Retrieve the current forecast data for a given location and parameters.
 // Data is provided by the Open-Meteo API.
 func GetForecast(params ForecastParams) (ForecastResponse, error) {
-	url := fmt.Sprintf("%s?latitude=%f&longitude=%f", FORECAST_API_URL, params.Latitude, params.Longitude)
+	return GetForecastFrom(FORECAST_API_URL, params)
+}
+
+// Same as GetForecast, against any endpoint that implements the Forecast V1 API.
+func GetForecastFrom(apiURL string, params ForecastParams) (ForecastResponse, error) {
+	url := fmt.Sprintf("%s?latitude=%f&longitude=%f", apiURL, params.Latitude, params.Longitude)
 	if len(params.Current) > 0 {
 		currentVars := writeVariableCSV(params.Current)
 		url += fmt.Sprintf("&current=%s", currentVars)
 	}
+	if len(params.Hourly) > 0 {
+		hourlyVars := writeVariableCSV(params.Hourly)
+		url += fmt.Sprintf("&hourly=%s", hourlyVars)
+	}
 	if len(params.Daily) > 0 {
 		dailyVars := writeVariableCSV(params.Daily)
 		url += fmt.Sprintf("&daily=%s", dailyVars)
 	}
-
-	resp, err := http.Get(url)
-	if err != nil {
-		return ForecastResponse{}, err
+	if len(params.Models) > 0 {
+		models := writeVariableCSV(params.Models)
+		url += fmt.Sprintf("&models=%s", models)
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return ForecastResponse{}, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	url += params.Units.query()
+	if params.Timezone != "" {
+		url += fmt.Sprintf("&timezone=%s", params.Timezone)
 	}
-	decoder := json.NewDecoder(resp.Body)
+
 	var response ForecastResponse
-	err = decoder.Decode(&response)
-	if err != nil {
+	if err := getJSON(url, &response); err != nil {
 		return ForecastResponse{}, err
 	}
 
 	return response, nil
 }
 
+// Split a response into one response per requested model.
+// When more than one model is requested, the API suffixes every variable
+// with the model name, e.g. `temperature_2m_gfs_seamless`.
+func (r ForecastResponse) ByModel(models []WeatherModel) map[WeatherModel]ForecastResponse {
+	split := make(map[WeatherModel]ForecastResponse, len(models))
+	if len(models) == 1 {
+		split[models[0]] = r
+		return split
+	}
+
+	for _, model := range models {
+		res := r
+		res.CurrentUnits = pickModel(r.CurrentUnits, model)
+		res.Current = pickModel(r.Current, model)
+		res.HourlyUnits = pickModel(r.HourlyUnits, model)
+		res.Hourly = pickModel(r.Hourly, model)
+		res.DailyUnits = pickModel(r.DailyUnits, model)
+		res.Daily = pickModel(r.Daily, model)
+		split[model] = res
+	}
+	return split
+}
+
+// Keep the variables of a single model, without the suffix.
+// Shared keys such as `time` are kept as they are.
+func pickModel(values map[string]any, model WeatherModel) map[string]any {
+	suffix := "_" + string(model)
+	picked := make(map[string]any)
+	for k, v := range values {
+		if name, ok := strings.CutSuffix(k, suffix); ok {
+			picked[name] = v
+		} else if k == "time" || k == "interval" {
+			picked[k] = v
+		}
+	}
+	return picked
+}
+
+// Location of the response timestamps.
+// Falls back to the UTC offset when the timezone name is unknown to the system.
+func (r ForecastResponse) Location() *time.Location {
+	if loc, err := time.LoadLocation(r.Timezone); err == nil && r.Timezone != "" {
+		return loc
+	}
+	return time.FixedZone(r.TimezoneAbbrev, r.UTCOffsetSeconds)
+}
+
+// Broad group of WMO weather codes, e.g. every kind of rain.
+type Category string
+
+const (
+	CategoryUnknown      Category = "unknown"
+	CategoryClear        Category = "clear"
+	CategoryPartlyCloudy Category = "partly_cloudy"
+	CategoryOvercast     Category = "overcast"
+	CategoryFog          Category = "fog"
+	CategoryDrizzle      Category = "drizzle"
+	CategoryRain         Category = "rain"
+	CategorySnow         Category = "snow"
+	CategoryThunderstorm Category = "thunderstorm"
+)
+
+// Category of a WMO weather code. Showers count as rain or snow.
+func CategoryOf(code float64) Category {
+	switch code {
+	case 0:
+		return CategoryClear
+	case 1, 2:
+		return CategoryPartlyCloudy
+	case 3:
+		return CategoryOvercast
+	case 45, 48:
+		return CategoryFog
+	case 51, 53, 55, 56, 57:
+		return CategoryDrizzle
+	case 61, 63, 65, 66, 67, 80, 81, 82:
+		return CategoryRain
+	case 71, 73, 75, 77, 85, 86:
+		return CategorySnow
+	case 95, 96, 99:
+		return CategoryThunderstorm
+	default:
+		return CategoryUnknown
+	}
+}
+
 func MapWeatherCode(code float64) string {
 	wmoCodes := map[float64]string{
 		0:  "Clear",
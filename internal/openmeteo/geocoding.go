@@ -1,9 +1,7 @@
 package openmeteo
 
 import (
-	"encoding/json"
 	"fmt"
-	"net/http"
 	"net/url"
 	"strconv"
 )
@@ -14,17 +12,28 @@ import (
 type GeocodingParams struct {
 	Name  string
 	Count int
+	// ISO 639-1 code for the names in the results. Empty uses English.
+	Language string
 }
 
 // Result item in response array.
 type GeocodingResult struct {
 	ID        int     `json:"id"`
 	Name      string  `json:"name"`
+	Admin1    string  `json:"admin1,omitempty"`
 	Country   string  `json:"country"`
 	Latitude  float64 `json:"latitude"`
 	Longitude float64 `json:"longitude"`
 }
 
+// Name and country, e.g. "Quito, Ecuador". Just the name when there is no country.
+func (r GeocodingResult) Label() string {
+	if r.Country == "" {
+		return r.Name
+	}
+	return r.Name + ", " + r.Country
+}
+
 // Response from the Open-Meteo Geocoding V1 API.
 type GeocodingResponse struct {
 	Results []GeocodingResult `json:"results"`
@@ -45,22 +54,13 @@ func SearchLocation(params GeocodingParams) (GeocodingResponse, error) {
 	if params.Count != 0 {
 		query.Set("count", strconv.Itoa(params.Count))
 	}
-	searchURL.RawQuery = query.Encode()
-
-	resp, err := http.Get(searchURL.String())
-	if err != nil {
-		return GeocodingResponse{}, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return GeocodingResponse{}, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	if params.Language != "" {
+		query.Set("language", params.Language)
 	}
+	searchURL.RawQuery = query.Encode()
 
-	decoder := json.NewDecoder(resp.Body)
 	var response GeocodingResponse
-	err = decoder.Decode(&response)
-	if err != nil {
+	if err := getJSON(searchURL.String(), &response); err != nil {
 		return GeocodingResponse{}, err
 	}
 
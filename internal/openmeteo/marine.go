@@ -0,0 +1,77 @@
+package openmeteo
+
+import (
+	"fmt"
+)
+
+// Parameters for the Open-Meteo Marine Weather V1 API.
+// These are not exclusive. Check the docs for additional ones.
+// https://open-meteo.com/en/docs/marine-weather-api
+type MarineParams struct {
+	Latitude  float64
+	Longitude float64
+	Current   []MarineVariables
+}
+
+// Response from the Open-Meteo Marine Weather V1 API.
+// Points away from the sea come back with `nil` values.
+type MarineResponse struct {
+	Latitude         float64        `json:"latitude"`
+	Longitude        float64        `json:"longitude"`
+	Elevation        float64        `json:"elevation"`
+	GenerationTimeMs float64        `json:"generation_time_ms"`
+	UTCOffsetSeconds int            `json:"utc_offset_seconds"`
+	Timezone         string         `json:"timezone"`
+	TimezoneAbbrev   string         `json:"timezone_abbreviation"`
+	CurrentUnits     map[string]any `json:"current_units"`
+	Current          map[string]any `json:"current"`
+}
+
+// Variables available to request from the Open-Meteo Marine Weather V1 API.
+type MarineVariables string
+
+const (
+	WaveHeight            MarineVariables = "wave_height"
+	WaveDirection         MarineVariables = "wave_direction"
+	WavePeriod            MarineVariables = "wave_period"
+	WindWaveHeight        MarineVariables = "wind_wave_height"
+	SwellWaveHeight       MarineVariables = "swell_wave_height"
+	SwellWaveDirection    MarineVariables = "swell_wave_direction"
+	SwellWavePeriod       MarineVariables = "swell_wave_period"
+	SeaSurfaceTemperature MarineVariables = "sea_surface_temperature"
+	OceanCurrentVelocity  MarineVariables = "ocean_current_velocity"
+	OceanCurrentDirection MarineVariables = "ocean_current_direction"
+)
+
+const MARINE_API_URL = "https://marine-api.open-meteo.com/v1/marine"
+
+// Retrieve the current sea conditions for a given location and parameters.
+// Data is provided by the Open-Meteo API.
+func GetMarine(params MarineParams) (MarineResponse, error) {
+	url := fmt.Sprintf("%s?latitude=%f&longitude=%f", MARINE_API_URL, params.Latitude, params.Longitude)
+	if len(params.Current) > 0 {
+		currentVars := writeVariableCSV(params.Current)
+		url += fmt.Sprintf("&current=%s", currentVars)
+	}
+
+	var response MarineResponse
+	if err := getJSON(url, &response); err != nil {
+		return MarineResponse{}, err
+	}
+
+	return response, nil
+}
+
+// Whether the response has any sea data at all.
+// Inland locations get a valid response where every variable is `nil`.
+func (r MarineResponse) HasData() bool {
+	for k, v := range r.Current {
+		if k == "time" || k == "interval" {
+			continue
+		}
+		if _, ok := v.(float64); ok {
+			return true
+		}
+	}
+	return false
+}
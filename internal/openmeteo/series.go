@@ -0,0 +1,38 @@
+package openmeteo
+
+// Timestamps of a daily or hourly series, as sent by the API.
+func SeriesTimes(series map[string]any) []string {
+	values, _ := series["time"].([]any)
+	times := make([]string, 0, len(values))
+	for _, v := range values {
+		if t, ok := v.(string); ok {
+			times = append(times, t)
+		}
+	}
+	return times
+}
+
+// Value of a variable at position `i` of a daily or hourly series.
+// Missing data is reported as not ok, the API sends it as `null`.
+func SeriesValue(series map[string]any, name string, i int) (float64, bool) {
+	values, ok := series[name].([]any)
+	if !ok || i < 0 || i >= len(values) {
+		return 0, false
+	}
+	v, ok := values[i].(float64)
+	return v, ok
+}
+
+// Text value of a variable at position `i` of a daily or hourly series,
+// used by variables like `sunrise` that hold timestamps.
+func SeriesString(series map[string]any, name string, i int) (string, bool) {
+	values, ok := series[name].([]any)
+	if !ok || i < 0 || i >= len(values) {
+		return "", false
+	}
+	v, ok := values[i].(string)
+	return v, ok
+}
+
+// Layout of the timestamps sent by the API, in the timezone of the response.
+const TIME_LAYOUT = "2006-01-02T15:04"
@@ -0,0 +1,51 @@
+package openmeteo
+
+import (
+	"fmt"
+)
+
+type TemperatureUnit string
+
+const (
+	Celsius    TemperatureUnit = "celsius"
+	Fahrenheit TemperatureUnit = "fahrenheit"
+)
+
+type WindSpeedUnit string
+
+const (
+	KilometersPerHour WindSpeedUnit = "kmh"
+	MetersPerSecond   WindSpeedUnit = "ms"
+	MilesPerHour      WindSpeedUnit = "mph"
+	Knots             WindSpeedUnit = "kn"
+)
+
+type PrecipitationUnit string
+
+const (
+	Millimeters PrecipitationUnit = "mm"
+	Inches      PrecipitationUnit = "inch"
+)
+
+// Units for the values returned by the forecast and archive APIs.
+// Empty fields use the API defaults: celsius, km/h and millimeters.
+type Units struct {
+	Temperature   TemperatureUnit   `json:"temperature,omitempty"`
+	WindSpeed     WindSpeedUnit     `json:"wind_speed,omitempty"`
+	Precipitation PrecipitationUnit `json:"precipitation,omitempty"`
+}
+
+// Compose the query parameters for the non-default units.
+func (u Units) query() string {
+	query := ""
+	if u.Temperature != "" {
+		query += fmt.Sprintf("&temperature_unit=%s", u.Temperature)
+	}
+	if u.WindSpeed != "" {
+		query += fmt.Sprintf("&wind_speed_unit=%s", u.WindSpeed)
+	}
+	if u.Precipitation != "" {
+		query += fmt.Sprintf("&precipitation_unit=%s", u.Precipitation)
+	}
+	return query
+}
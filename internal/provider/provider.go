@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/esferadigital/clima/internal/config"
+	"github.com/esferadigital/clima/internal/openmeteo"
+)
+
+// Endpoint that implements the Open-Meteo Forecast V1 API.
+type Provider struct {
+	Name string
+	URL  string
+}
+
+// Providers that can be referenced by name alone in the config.
+var builtin = map[string]string{
+	"open-meteo": openmeteo.FORECAST_API_URL,
+}
+
+// Ordered list of providers. The first one that answers wins.
+type Chain []Provider
+
+// Build the provider chain from the user config.
+func FromConfig(cfg config.Config) (Chain, error) {
+	chain := make(Chain, 0, len(cfg.Providers))
+	for _, p := range cfg.Providers {
+		url := p.URL
+		if url == "" {
+			var ok bool
+			if url, ok = builtin[p.Name]; !ok {
+				return nil, fmt.Errorf("unknown provider %q: set a url for it", p.Name)
+			}
+		}
+		chain = append(chain, Provider{Name: p.Name, URL: url})
+	}
+	if len(chain) == 0 {
+		return nil, errors.New("no forecast providers configured")
+	}
+	return chain, nil
+}
+
+// Retrieve the forecast from the first provider that succeeds.
+// Returns the provider that answered, or the errors of all of them.
+func (c Chain) GetForecast(params openmeteo.ForecastParams) (openmeteo.ForecastResponse, Provider, error) {
+	var errs []error
+	for _, p := range c {
+		res, err := openmeteo.GetForecastFrom(p.URL, params)
+		if err == nil {
+			return res, p, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", p.Name, err))
+	}
+	return openmeteo.ForecastResponse{}, Provider{}, errors.Join(errs...)
+}
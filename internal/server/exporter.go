@@ -0,0 +1,62 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/esferadigital/clima/internal/config"
+	"github.com/esferadigital/clima/internal/forecast"
+	"github.com/esferadigital/clima/internal/metrics"
+	"github.com/esferadigital/clima/internal/openmeteo"
+)
+
+// Locations exported as metrics: those of the config, or the home location.
+func ExportedLocations(cfg config.Config) []string {
+	if len(cfg.Metrics.Locations) > 0 {
+		return cfg.Metrics.Locations
+	}
+	if cfg.Home != "" {
+		return []string{cfg.Home}
+	}
+	return nil
+}
+
+// Update the conditions of the locations every interval until the context is done.
+// Failures are reported to log and marked in the metrics.
+func Export(ctx context.Context, cfg config.Config, locations []string, every time.Duration, log io.Writer) {
+	// Metrics are in metric units, so they get their own cache entries
+	cfg.Units = openmeteo.Units{
+		Temperature:   openmeteo.Celsius,
+		WindSpeed:     openmeteo.MetersPerSecond,
+		Precipitation: openmeteo.Millimeters,
+	}
+	var locs resolver
+	for {
+		for _, query := range locations {
+			if err := export(cfg, &locs, query); err != nil {
+				metrics.SetFailed(query)
+				fmt.Fprintf(log, "Failed to export %q: %v\n", query, err)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(every):
+		}
+	}
+}
+
+func export(cfg config.Config, locs *resolver, query string) error {
+	loc, err := locs.resolve(query)
+	if err != nil {
+		return err
+	}
+	cached, err := forecast.Get(cfg, loc)
+	if err != nil {
+		return err
+	}
+	metrics.SetConditions(query, cached.FetchedAt, cached.Forecast.Current)
+	return nil
+}
@@ -0,0 +1,188 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/esferadigital/clima/internal/config"
+	"github.com/esferadigital/clima/internal/forecast"
+	"github.com/esferadigital/clima/internal/location"
+	"github.com/esferadigital/clima/internal/metrics"
+	"github.com/esferadigital/clima/internal/openmeteo"
+	"github.com/esferadigital/clima/internal/store"
+)
+
+// Local HTTP JSON API over the recent locations and the forecast cache.
+//
+//	GET /v1/locations                        recent locations
+//	GET /v1/forecast?location=<query>        forecast by name, alias or "lat,lon"
+//	GET /v1/current?lat=<lat>&lon=<lon>      current conditions at coordinates
+//	GET /metrics                             Prometheus and OpenMetrics metrics
+//
+// Forecasts come from the cache while they are fresh, and responses say
+// for how long they stay so with Cache-Control.
+func New(cfg config.Config) http.Handler {
+	s := &server{cfg: cfg}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/locations", s.locations)
+	mux.HandleFunc("GET /v1/forecast", s.forecast)
+	mux.HandleFunc("GET /v1/current", s.current)
+	mux.Handle("GET /metrics", metrics.Handler())
+	return mux
+}
+
+type server struct {
+	cfg      config.Config
+	resolver resolver
+}
+
+// ---- responses ----
+
+type forecastResponse struct {
+	Location  openmeteo.GeocodingResult  `json:"location"`
+	FetchedAt time.Time                  `json:"fetched_at"`
+	Provider  string                     `json:"provider"`
+	Forecast  openmeteo.ForecastResponse `json:"forecast"`
+}
+
+type currentResponse struct {
+	Location     openmeteo.GeocodingResult `json:"location"`
+	FetchedAt    time.Time                 `json:"fetched_at"`
+	Provider     string                    `json:"provider"`
+	Timezone     string                    `json:"timezone"`
+	CurrentUnits map[string]any            `json:"current_units"`
+	Current      map[string]any            `json:"current"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// ---- handlers ----
+
+func (s *server) locations(w http.ResponseWriter, r *http.Request) {
+	locations, err := store.LoadRecentLocations()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-cache")
+	writeJSON(w, http.StatusOK, locations)
+}
+
+func (s *server) forecast(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("location")
+	if query == "" {
+		writeError(w, http.StatusBadRequest, errors.New("missing location"))
+		return
+	}
+	loc, err := s.resolver.resolve(query)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	cached, err := forecast.Get(s.cfg, loc)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	s.setCacheHeaders(w, cached)
+	writeJSON(w, http.StatusOK, forecastResponse{
+		Location:  loc,
+		FetchedAt: cached.FetchedAt,
+		Provider:  cached.Provider,
+		Forecast:  cached.Forecast,
+	})
+}
+
+func (s *server) current(w http.ResponseWriter, r *http.Request) {
+	lat, err := coordinate(r, "lat", 90)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	long, err := coordinate(r, "lon", 180)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	loc := location.AtCoordinates(lat, long)
+
+	cached, err := forecast.Get(s.cfg, loc)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	s.setCacheHeaders(w, cached)
+	writeJSON(w, http.StatusOK, currentResponse{
+		Location:     loc,
+		FetchedAt:    cached.FetchedAt,
+		Provider:     cached.Provider,
+		Timezone:     cached.Forecast.Timezone,
+		CurrentUnits: cached.Forecast.CurrentUnits,
+		Current:      cached.Forecast.Current,
+	})
+}
+
+// ---- helpers ----
+
+// Let clients reuse the response until the cached forecast is due for a fetch.
+func (s *server) setCacheHeaders(w http.ResponseWriter, cached forecast.Cached) {
+	remaining := max(forecast.TTL(s.cfg)-time.Since(cached.FetchedAt), 0)
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(remaining.Seconds())))
+	w.Header().Set("Last-Modified", cached.FetchedAt.UTC().Format(http.TimeFormat))
+}
+
+// Locations by the query that found them, so each query is only looked up once.
+// Failed lookups are not kept and are tried again.
+type resolver struct {
+	mu       sync.Mutex
+	resolved map[string]openmeteo.GeocodingResult
+}
+
+func (r *resolver) resolve(query string) (openmeteo.GeocodingResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if loc, ok := r.resolved[query]; ok {
+		return loc, nil
+	}
+	loc, err := location.Resolve(query)
+	if err != nil {
+		return openmeteo.GeocodingResult{}, err
+	}
+	if r.resolved == nil {
+		r.resolved = map[string]openmeteo.GeocodingResult{}
+	}
+	r.resolved[query] = loc
+	return loc, nil
+}
+
+// Query parameter holding a coordinate between -limit and limit.
+func coordinate(r *http.Request, name string, limit float64) (float64, error) {
+	text := r.URL.Query().Get(name)
+	if text == "" {
+		return 0, fmt.Errorf("missing %s", name)
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil || value < -limit || value > limit {
+		return 0, fmt.Errorf("%s must be a number between %g and %g", name, -limit, limit)
+	}
+	return value, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	// The status is sent, a failed write can only mean the client left
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
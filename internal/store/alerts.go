@@ -0,0 +1,70 @@
+package store
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/esferadigital/clima/internal/config"
+)
+
+const ACTIVE_ALERTS_FILE = "clima_alerts.json"
+
+func getAlertsPath() (string, error) {
+	configDir, err := config.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, ACTIVE_ALERTS_FILE), nil
+}
+
+// Alerts that were notified and still hold, by "rule@location", so that
+// separate runs of clima watch --once do not notify them again.
+func LoadActiveAlerts() (map[string]bool, error) {
+	path, err := getAlertsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]bool{}, nil
+		}
+		return nil, err
+	}
+
+	var keys []string
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, err
+	}
+
+	active := map[string]bool{}
+	for _, key := range keys {
+		active[key] = true
+	}
+	return active, nil
+}
+
+func SaveActiveAlerts(active map[string]bool) error {
+	path, err := getAlertsPath()
+	if err != nil {
+		return err
+	}
+
+	keys := []string{}
+	for key, holding := range active {
+		if holding {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+
+	data, err := json.MarshalIndent(keys, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
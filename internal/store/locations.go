@@ -2,9 +2,12 @@ package store
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/esferadigital/clima/internal/config"
 	"github.com/esferadigital/clima/internal/openmeteo"
 )
 
@@ -12,20 +15,29 @@ const RECENT_LOCATIONS_FILE = "clima_recent.json"
 const MAX_RECENT_LOCATIONS = 5
 
 func getRecentPath() (string, error) {
-	homeDir, err := os.UserHomeDir()
+	configDir, err := config.Dir()
 	if err != nil {
 		return "", err
 	}
+	return filepath.Join(configDir, RECENT_LOCATIONS_FILE), nil
+}
 
-	configDir := filepath.Join(homeDir, ".config", "clima")
-	if err := os.MkdirAll(configDir, 0755); err != nil {
-		return "", err
-	}
+// Recent location, with an optional alias chosen by the user.
+// The geocoding fields are stored inline, so older files still load.
+type Location struct {
+	openmeteo.GeocodingResult
+	Alias string `json:"alias,omitempty"`
+}
 
-	return filepath.Join(configDir, RECENT_LOCATIONS_FILE), nil
+// Alias if there is one, otherwise the label of the place.
+func (l Location) DisplayName() string {
+	if l.Alias != "" {
+		return l.Alias
+	}
+	return l.Label()
 }
 
-func saveRecent(locations []openmeteo.GeocodingResult) error {
+func saveRecent(locations []Location) error {
 	path, err := getRecentPath()
 	if err != nil {
 		return err
@@ -39,7 +51,7 @@ func saveRecent(locations []openmeteo.GeocodingResult) error {
 	return os.WriteFile(path, data, 0644)
 }
 
-func LoadRecentLocations() ([]openmeteo.GeocodingResult, error) {
+func LoadRecentLocations() ([]Location, error) {
 	path, err := getRecentPath()
 	if err != nil {
 		return nil, err
@@ -48,12 +60,12 @@ func LoadRecentLocations() ([]openmeteo.GeocodingResult, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return []openmeteo.GeocodingResult{}, nil
+			return []Location{}, nil
 		}
 		return nil, err
 	}
 
-	var locations []openmeteo.GeocodingResult
+	var locations []Location
 	if err := json.Unmarshal(data, &locations); err != nil {
 		return nil, err
 	}
@@ -61,22 +73,30 @@ func LoadRecentLocations() ([]openmeteo.GeocodingResult, error) {
 	return locations, nil
 }
 
+func indexOf(locations []Location, id int) int {
+	for i, loc := range locations {
+		if loc.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
 func AddRecentLocation(location openmeteo.GeocodingResult) error {
 	locations, err := LoadRecentLocations()
 	if err != nil {
 		return err
 	}
 
-	// Remove if already exists
-	for i, loc := range locations {
-		if loc.ID == location.ID {
-			locations = append(locations[:i], locations[i+1:]...)
-			break
-		}
+	// Remove if already exists, keeping its alias
+	added := Location{GeocodingResult: location}
+	if i := indexOf(locations, location.ID); i >= 0 {
+		added.Alias = locations[i].Alias
+		locations = append(locations[:i], locations[i+1:]...)
 	}
 
 	// Add to front
-	locations = append([]openmeteo.GeocodingResult{location}, locations...)
+	locations = append([]Location{added}, locations...)
 
 	// Keep only the most recent MAX_RECENT_LOCATIONS
 	if len(locations) > MAX_RECENT_LOCATIONS {
@@ -86,3 +106,56 @@ func AddRecentLocation(location openmeteo.GeocodingResult) error {
 	return saveRecent(locations)
 }
 
+func RemoveRecentLocation(id int) error {
+	locations, err := LoadRecentLocations()
+	if err != nil {
+		return err
+	}
+
+	i := indexOf(locations, id)
+	if i < 0 {
+		return nil
+	}
+	return saveRecent(append(locations[:i], locations[i+1:]...))
+}
+
+func ClearRecentLocations() error {
+	return saveRecent([]Location{})
+}
+
+// Move a location by offset places, e.g. -1 moves it up one.
+// Moves past either end stop there. Returns the new position.
+func MoveRecentLocation(id int, offset int) (int, error) {
+	locations, err := LoadRecentLocations()
+	if err != nil {
+		return 0, err
+	}
+
+	from := indexOf(locations, id)
+	if from < 0 {
+		return 0, fmt.Errorf("location %d is not in the recent list", id)
+	}
+	to := min(max(from+offset, 0), len(locations)-1)
+
+	moved := locations[from]
+	locations = append(locations[:from], locations[from+1:]...)
+	locations = append(locations[:to], append([]Location{moved}, locations[to:]...)...)
+
+	return to, saveRecent(locations)
+}
+
+// Set the alias of a location. An empty alias goes back to "Name, Country".
+func RenameRecentLocation(id int, alias string) error {
+	locations, err := LoadRecentLocations()
+	if err != nil {
+		return err
+	}
+
+	i := indexOf(locations, id)
+	if i < 0 {
+		return fmt.Errorf("location %d is not in the recent list", id)
+	}
+	locations[i].Alias = strings.TrimSpace(alias)
+
+	return saveRecent(locations)
+}
@@ -0,0 +1,64 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/esferadigital/clima/internal/openmeteo"
+)
+
+func TestMoveRecentLocation(t *testing.T) {
+	tests := []struct {
+		name   string
+		id     int
+		offset int
+		want   []int
+		to     int
+		err    bool
+	}{
+		{name: "up one", id: 3, offset: -1, want: []int{1, 3, 2, 4}, to: 1},
+		{name: "down one", id: 2, offset: 1, want: []int{1, 3, 2, 4}, to: 2},
+		{name: "stops at the top", id: 3, offset: -5, want: []int{3, 1, 2, 4}, to: 0},
+		{name: "stops at the bottom", id: 1, offset: 9, want: []int{2, 3, 4, 1}, to: 3},
+		{name: "nowhere", id: 2, offset: 0, want: []int{1, 2, 3, 4}, to: 1},
+		{name: "not in the list", id: 7, offset: 1, want: []int{1, 2, 3, 4}, err: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", t.TempDir())
+			// Added in reverse, as the last added goes first
+			for _, id := range []int{4, 3, 2, 1} {
+				if err := AddRecentLocation(openmeteo.GeocodingResult{ID: id}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := RenameRecentLocation(tt.id, "Moved"); err != nil && !tt.err {
+				t.Fatal(err)
+			}
+
+			to, err := MoveRecentLocation(tt.id, tt.offset)
+			if (err != nil) != tt.err {
+				t.Fatalf("err = %v, want error %v", err, tt.err)
+			}
+			if err == nil && to != tt.to {
+				t.Errorf("moved to %d, want %d", to, tt.to)
+			}
+
+			locations, err := LoadRecentLocations()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ids []int
+			for _, loc := range locations {
+				ids = append(ids, loc.ID)
+				if loc.ID == tt.id && loc.Alias != "Moved" {
+					t.Errorf("alias = %q after moving, want Moved", loc.Alias)
+				}
+			}
+			if !reflect.DeepEqual(ids, tt.want) {
+				t.Errorf("order = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
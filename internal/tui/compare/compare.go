@@ -0,0 +1,363 @@
+package compare
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/esferadigital/clima/internal/config"
+	"github.com/esferadigital/clima/internal/i18n"
+	"github.com/esferadigital/clima/internal/openmeteo"
+	"github.com/esferadigital/clima/internal/provider"
+	"github.com/esferadigital/clima/internal/tui/errorview"
+	"github.com/esferadigital/clima/internal/tui/keybind"
+	"github.com/esferadigital/clima/internal/tui/nav"
+	"github.com/esferadigital/clima/internal/tui/theme"
+)
+
+// ---- styles ----
+
+var cell = lipgloss.NewStyle().Width(22)
+
+// Colors come from the theme, which is only known once the config is loaded.
+func label() lipgloss.Style {
+	return theme.Current().Label.Width(16)
+}
+
+// ---- keymap ----
+
+type keyMap struct {
+	back    key.Binding
+	refresh key.Binding
+	quit    key.Binding
+}
+
+func (k keyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.back, k.refresh, k.quit}
+}
+
+func (k keyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.back}, {k.refresh}, {k.quit},
+	}
+}
+
+func newKeyMap() keyMap {
+	return keyMap{
+		back:    keybind.Binding(keybind.Back, i18n.T("back to forecast")),
+		refresh: keybind.Binding(keybind.Refresh, i18n.T("refresh")),
+		quit:    keybind.Binding(keybind.Quit, i18n.T("quit")),
+	}
+}
+
+// ---- msg ----
+
+type dataMsg struct {
+	columns []column
+	// Providers that did not answer, while others did
+	failed []error
+}
+
+type errorMsg struct {
+	err error
+}
+
+// ---- cmd ----
+
+// Ask every provider for every model at once, so each gets its own columns.
+func getForecastsCmd(cfg config.Config, lat float64, long float64) tea.Cmd {
+	return func() tea.Msg {
+		providers, err := provider.FromConfig(cfg)
+		if err != nil {
+			return errorMsg{
+				err: err,
+			}
+		}
+		params := openmeteo.ForecastParams{
+			Latitude:  lat,
+			Longitude: long,
+			Units:     cfg.Units,
+			Current:   currentVariables(),
+			Daily:     dailyVariables(),
+			Models:    cfg.CompareModels,
+		}
+
+		responses := make([]openmeteo.ForecastResponse, len(providers))
+		errs := make([]error, len(providers))
+		var wg sync.WaitGroup
+		for i, p := range providers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				responses[i], errs[i] = openmeteo.GetForecastFrom(p.URL, params)
+			}()
+		}
+		wg.Wait()
+
+		var msg dataMsg
+		for i, p := range providers {
+			if errs[i] != nil {
+				msg.failed = append(msg.failed, fmt.Errorf("%s: %w", p.Name, errs[i]))
+				continue
+			}
+			byModel := responses[i].ByModel(cfg.CompareModels)
+			for _, model := range cfg.CompareModels {
+				msg.columns = append(msg.columns, column{provider: p.Name, model: model, forecast: byModel[model]})
+			}
+		}
+		if len(msg.columns) == 0 {
+			return errorMsg{
+				err: errors.Join(msg.failed...),
+			}
+		}
+		return msg
+	}
+}
+
+// Check the models to compare, as done at startup.
+func Validate(cfg config.Config) error {
+	if len(cfg.CompareModels) == 0 {
+		return errors.New("no models to compare: list at least one in compare_models")
+	}
+	return nil
+}
+
+// ---- helpers ----
+
+// Forecast of one model from one provider.
+type column struct {
+	provider string
+	model    openmeteo.WeatherModel
+	forecast openmeteo.ForecastResponse
+}
+
+// A variable compared across models.
+// Exactly one of `current` or `daily` is set.
+type row struct {
+	name    string
+	current openmeteo.CurrentWeatherVariables
+	daily   openmeteo.DailyWeatherVariables
+}
+
+var rows = []row{
+	{name: "Temperature", current: openmeteo.Temperature2m},
+	{name: "Feels like", current: openmeteo.ApparentTemperature},
+	{name: "Min", daily: openmeteo.Temperature2mMin},
+	{name: "Max", daily: openmeteo.Temperature2mMax},
+	{name: "Humidity", current: openmeteo.RelativeHumidity2m},
+	{name: "Wind", current: openmeteo.WindSpeed10m},
+	{name: "Wind gusts", current: openmeteo.WindGusts10m},
+	{name: "Precipitation", current: openmeteo.Precipitation},
+	{name: "Cloud cover", current: openmeteo.CloudCover},
+	{name: "Pressure", current: openmeteo.SeaLevelPressure},
+}
+
+func currentVariables() []openmeteo.CurrentWeatherVariables {
+	vars := []openmeteo.CurrentWeatherVariables{openmeteo.WeatherCode}
+	for _, r := range rows {
+		if r.current != "" {
+			vars = append(vars, r.current)
+		}
+	}
+	return vars
+}
+
+func dailyVariables() []openmeteo.DailyWeatherVariables {
+	var vars []openmeteo.DailyWeatherVariables
+	for _, r := range rows {
+		if r.daily != "" {
+			vars = append(vars, r.daily)
+		}
+	}
+	return vars
+}
+
+// Value and unit of a row for a single model forecast.
+// Today's value is used for daily variables.
+func (r row) value(f openmeteo.ForecastResponse) (float64, string, bool) {
+	if r.current != "" {
+		v, ok := f.Current[string(r.current)].(float64)
+		unit, _ := f.CurrentUnits[string(r.current)].(string)
+		return v, unit, ok
+	}
+	values, ok := f.Daily[string(r.daily)].([]any)
+	if !ok || len(values) == 0 {
+		return 0, "", false
+	}
+	v, ok := values[0].(float64)
+	unit, _ := f.DailyUnits[string(r.daily)].(string)
+	return v, unit, ok
+}
+
+// ---- model ----
+
+type view int
+
+const (
+	viewLoading = iota
+	viewReady
+	viewError
+)
+
+type Model struct {
+	view     view
+	failure  errorview.Model
+	ellipsis spinner.Model
+	cfg      config.Config
+	location openmeteo.GeocodingResult
+	columns  []column
+	failed   []error
+	keys     keyMap
+	help     help.Model
+}
+
+func (m Model) Init() tea.Cmd {
+	return tea.Batch(
+		getForecastsCmd(m.cfg, m.location.Latitude, m.location.Longitude),
+		m.ellipsis.Tick,
+	)
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.view == viewError {
+			if key.Matches(msg, m.failure.Keys.Retry) {
+				m.view = viewLoading
+				return m, tea.Batch(getForecastsCmd(m.cfg, m.location.Latitude, m.location.Longitude), m.ellipsis.Tick)
+			}
+			if key.Matches(msg, m.failure.Keys.Back) {
+				return m, nav.Back()
+			}
+		}
+		if key.Matches(msg, m.keys.back) {
+			return m, nav.Back()
+		}
+		if key.Matches(msg, m.keys.refresh) && m.view != viewLoading {
+			m.view = viewLoading
+			return m, tea.Batch(getForecastsCmd(m.cfg, m.location.Latitude, m.location.Longitude), m.ellipsis.Tick)
+		}
+		if key.Matches(msg, m.keys.quit) {
+			return m, tea.Quit
+		}
+	case dataMsg:
+		m.columns = msg.columns
+		m.failed = msg.failed
+		m.view = viewReady
+		return m, nil
+	case errorMsg:
+		m.view = viewError
+		m.failure = errorview.New(i18n.T("Failed to compare forecasts"), msg.err, errorview.NewKeyMap(i18n.T("back to forecast")))
+		return m, nil
+	}
+
+	if m.view == viewLoading {
+		var cmd tea.Cmd
+		m.ellipsis, cmd = m.ellipsis.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m Model) View() string {
+	switch m.view {
+	case viewLoading:
+		return i18n.T("\nComparing models%s\n", m.ellipsis.View())
+	case viewReady:
+		s := "\n" + m.location.Label()
+		if len(m.cfg.Providers) == 1 {
+			s += theme.Current().Subtle.Render(i18n.T(" (via %s)", m.cfg.Providers[0].Name))
+		}
+		s += "\n\n"
+
+		// Columns are labelled by provider too once there are several
+		if len(m.cfg.Providers) > 1 {
+			var providers strings.Builder
+			providers.WriteString(label().Render(""))
+			for _, c := range m.columns {
+				providers.WriteString(theme.Current().Subtle.Inherit(cell).Render(c.provider))
+			}
+			s += providers.String() + "\n"
+		}
+		var header strings.Builder
+		header.WriteString(label().Render(""))
+		for _, c := range m.columns {
+			header.WriteString(theme.Current().Accent.Inherit(cell).Render(string(c.model)))
+		}
+		header.WriteString(label().Render(i18n.T("Spread")))
+		s += header.String()
+
+		for _, r := range rows {
+			var line strings.Builder
+			line.WriteString(label().Render(i18n.T(r.name)))
+
+			low, high := math.Inf(1), math.Inf(-1)
+			var spreadUnit string
+			for _, c := range m.columns {
+				v, unit, ok := r.value(c.forecast)
+				if !ok {
+					line.WriteString(cell.Render("-"))
+					continue
+				}
+				line.WriteString(cell.Render(i18n.Sprintf("%.1f %s", v, unit)))
+				low, high = math.Min(low, v), math.Max(high, v)
+				spreadUnit = unit
+			}
+			if high >= low {
+				line.WriteString(theme.Current().Subtle.Render(i18n.Sprintf("%.1f %s", high-low, spreadUnit)))
+			} else {
+				line.WriteString(theme.Current().Subtle.Render("-"))
+			}
+			s += "\n" + line.String()
+		}
+
+		var conditions strings.Builder
+		conditions.WriteString(label().Render(i18n.T("Conditions")))
+		for _, c := range m.columns {
+			code, ok := c.forecast.Current[string(openmeteo.WeatherCode)].(float64)
+			if !ok {
+				conditions.WriteString(cell.Render("-"))
+				continue
+			}
+			conditions.WriteString(cell.Render(i18n.T(openmeteo.MapWeatherCode(code))))
+		}
+		s += "\n\n" + conditions.String()
+
+		if len(m.failed) > 0 {
+			s += "\n"
+		}
+		for _, err := range m.failed {
+			s += "\n" + theme.Current().Subtle.Render(i18n.T("Not compared, %v", err))
+		}
+
+		return s + "\n\n" + m.help.View(m.keys)
+	case viewError:
+		return m.failure.View()
+	default:
+		return "\nunknown error state (compare)"
+	}
+}
+
+func New(location openmeteo.GeocodingResult, cfg config.Config) Model {
+	ellipsis := spinner.New()
+	ellipsis.Spinner = spinner.Ellipsis
+	ellipsis.Style = theme.Current().Accent
+
+	return Model{
+		view:     viewLoading,
+		ellipsis: ellipsis,
+		cfg:      cfg,
+		location: location,
+		keys:     newKeyMap(),
+		help:     theme.Help(),
+	}
+}
@@ -0,0 +1,76 @@
+package errorview
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/esferadigital/clima/internal/i18n"
+	"github.com/esferadigital/clima/internal/tui/keybind"
+	"github.com/esferadigital/clima/internal/tui/theme"
+)
+
+// Errors are wrapped to this width so long messages from the API stay readable.
+const WIDTH = 72
+
+// ---- keymap ----
+
+// Keys of an error screen. Routes match them in their own Update,
+// since only they know which command failed and where back leads.
+type KeyMap struct {
+	Retry key.Binding
+	Back  key.Binding
+	Quit  key.Binding
+}
+
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Retry, k.Back, k.Quit}
+}
+
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Retry},
+		{k.Back},
+		{k.Quit},
+	}
+}
+
+// Keys with the help text of the back key, e.g. "back to forecast".
+func NewKeyMap(back string) KeyMap {
+	return KeyMap{
+		Retry: keybind.Binding(keybind.Retry, i18n.T("retry")),
+		Back:  keybind.Binding(keybind.Back, back),
+		Quit:  keybind.Binding(keybind.Quit, i18n.T("quit")),
+	}
+}
+
+// ---- model ----
+
+// What went wrong, the error details and the keys to recover.
+type Model struct {
+	Keys  KeyMap
+	title string
+	err   error
+	help  help.Model
+}
+
+func (m Model) View() string {
+	s := "\n" + theme.Current().Accent.Render(m.title) + "\n"
+	if m.err != nil {
+		details := lipgloss.NewStyle().Width(WIDTH).Render(strings.TrimSpace(m.err.Error()))
+		s += "\n" + theme.Current().Subtle.Render(details) + "\n"
+	}
+	return s + "\n" + m.help.View(m.Keys)
+}
+
+// Error screen with a localized title and the keys of the route.
+func New(title string, err error, keys KeyMap) Model {
+	return Model{
+		Keys:  keys,
+		title: title,
+		err:   err,
+		help:  theme.Help(),
+	}
+}
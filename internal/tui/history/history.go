@@ -0,0 +1,301 @@
+package history
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/esferadigital/clima/internal/config"
+	"github.com/esferadigital/clima/internal/i18n"
+	"github.com/esferadigital/clima/internal/openmeteo"
+	"github.com/esferadigital/clima/internal/tui/errorview"
+	"github.com/esferadigital/clima/internal/tui/keybind"
+	"github.com/esferadigital/clima/internal/tui/nav"
+	"github.com/esferadigital/clima/internal/tui/theme"
+)
+
+// Days shown at once
+const WINDOW_DAYS = 7
+
+// ---- styles ----
+
+var (
+	dateCell = lipgloss.NewStyle().Width(18)
+	wide     = lipgloss.NewStyle().Width(CONDITIONS_WIDTH)
+	cell     = lipgloss.NewStyle().Width(10)
+)
+
+// Width of the conditions column. Longer descriptions are cut.
+const CONDITIONS_WIDTH = 22
+
+// Colors come from the theme, which is only known once the config is loaded.
+func label() lipgloss.Style {
+	return theme.Current().Label.Width(20)
+}
+
+// ---- keymap ----
+
+type keyMap struct {
+	earlier key.Binding
+	later   key.Binding
+	back    key.Binding
+	quit    key.Binding
+}
+
+func (k keyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.earlier, k.later, k.back, k.quit}
+}
+
+func (k keyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.earlier}, {k.later}, {k.back}, {k.quit},
+	}
+}
+
+func newKeyMap() keyMap {
+	return keyMap{
+		earlier: keybind.Binding(keybind.Earlier, i18n.T("earlier")),
+		later:   keybind.Binding(keybind.Later, i18n.T("later")),
+		back:    keybind.Binding(keybind.Back, i18n.T("back to forecast")),
+		quit:    keybind.Binding(keybind.Quit, i18n.T("quit")),
+	}
+}
+
+// ---- msg ----
+
+type dataMsg struct {
+	week     openmeteo.ArchiveResponse
+	lastYear openmeteo.ArchiveResponse
+}
+
+type errorMsg struct {
+	err error
+}
+
+// ---- cmd ----
+
+var dailyVariables = []openmeteo.DailyWeatherVariables{
+	openmeteo.DailyWeatherCode,
+	openmeteo.Temperature2mMin,
+	openmeteo.Temperature2mMax,
+	openmeteo.PrecipitationSum,
+}
+
+// Fetch the window ending at `end` and the location's today one year ago.
+func getHistoryCmd(cfg config.Config, location openmeteo.GeocodingResult, end time.Time, today time.Time) tea.Cmd {
+	return func() tea.Msg {
+		params := openmeteo.ArchiveParams{
+			Latitude:  location.Latitude,
+			Longitude: location.Longitude,
+			StartDate: end.AddDate(0, 0, -(WINDOW_DAYS - 1)),
+			EndDate:   end,
+			Daily:     dailyVariables,
+			Units:     cfg.Units,
+		}
+		week, err := openmeteo.GetArchive(params)
+		if err != nil {
+			return errorMsg{err: err}
+		}
+
+		lastYear := today.AddDate(-1, 0, 0)
+		params.StartDate = lastYear
+		params.EndDate = lastYear
+		year, err := openmeteo.GetArchive(params)
+		if err != nil {
+			return errorMsg{err: err}
+		}
+
+		return dataMsg{week: week, lastYear: year}
+	}
+}
+
+// ---- model ----
+
+type view int
+
+const (
+	viewLoading = iota
+	viewReady
+	viewError
+)
+
+type Model struct {
+	view     view
+	failure  errorview.Model
+	ellipsis spinner.Model
+	cfg      config.Config
+	location openmeteo.GeocodingResult
+	today    openmeteo.ForecastResponse
+	// Today at the location, the first day of its forecast
+	date     time.Time
+	end      time.Time
+	week     openmeteo.ArchiveResponse
+	lastYear openmeteo.ArchiveResponse
+	keys     keyMap
+	help     help.Model
+}
+
+func (m Model) Init() tea.Cmd {
+	return tea.Batch(getHistoryCmd(m.cfg, m.location, m.end, m.date), m.ellipsis.Tick)
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.view == viewError {
+			if key.Matches(msg, m.failure.Keys.Retry) {
+				m.view = viewLoading
+				return m, tea.Batch(getHistoryCmd(m.cfg, m.location, m.end, m.date), m.ellipsis.Tick)
+			}
+		}
+		if key.Matches(msg, m.keys.back) {
+			return m, nav.Back()
+		}
+		if key.Matches(msg, m.keys.earlier) && m.view != viewLoading {
+			m.end = m.end.AddDate(0, 0, -WINDOW_DAYS)
+			m.view = viewLoading
+			return m, tea.Batch(getHistoryCmd(m.cfg, m.location, m.end, m.date), m.ellipsis.Tick)
+		}
+		if key.Matches(msg, m.keys.later) && m.view != viewLoading {
+			latest := m.date.AddDate(0, 0, -1)
+			if !m.end.Before(latest) {
+				return m, nil
+			}
+			m.end = m.end.AddDate(0, 0, WINDOW_DAYS)
+			if m.end.After(latest) {
+				m.end = latest
+			}
+			m.view = viewLoading
+			return m, tea.Batch(getHistoryCmd(m.cfg, m.location, m.end, m.date), m.ellipsis.Tick)
+		}
+		if key.Matches(msg, m.keys.quit) {
+			return m, tea.Quit
+		}
+	case dataMsg:
+		m.week = msg.week
+		m.lastYear = msg.lastYear
+		m.view = viewReady
+		return m, nil
+	case errorMsg:
+		m.view = viewError
+		m.failure = errorview.New(i18n.T("Failed to get weather history"), msg.err, errorview.NewKeyMap(i18n.T("back to forecast")))
+		return m, nil
+	}
+
+	if m.view == viewLoading {
+		var cmd tea.Cmd
+		m.ellipsis, cmd = m.ellipsis.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m Model) View() string {
+	switch m.view {
+	case viewLoading:
+		return i18n.T("\nLoading history%s\n", m.ellipsis.View())
+	case viewReady:
+		s := "\n" + m.location.Label()
+		s += theme.Current().Subtle.Render(i18n.T(" (history)")) + "\n\n"
+
+		s += theme.Current().Subtle.Render(dateCell.Render(i18n.T("Date"))+wide.Render(i18n.T("Conditions"))+cell.Render(i18n.T("Min"))+cell.Render(i18n.T("Max"))+cell.Render(i18n.T("Precip."))) + "\n"
+		times := openmeteo.SeriesTimes(m.week.Daily)
+		for i, t := range times {
+			day := t
+			if date, err := time.Parse(time.DateOnly, t); err == nil {
+				day = i18n.Date(date)
+			}
+			s += m.dayRow(m.week, i, day) + "\n"
+		}
+
+		s += "\n" + theme.Current().Accent.Render(i18n.T("Same day last year"))
+		minToday, okMin := openmeteo.SeriesValue(m.today.Daily, string(openmeteo.Temperature2mMin), 0)
+		maxToday, okMax := openmeteo.SeriesValue(m.today.Daily, string(openmeteo.Temperature2mMax), 0)
+		minLast, okMinLast := openmeteo.SeriesValue(m.lastYear.Daily, string(openmeteo.Temperature2mMin), 0)
+		maxLast, okMaxLast := openmeteo.SeriesValue(m.lastYear.Daily, string(openmeteo.Temperature2mMax), 0)
+		unit, _ := m.lastYear.DailyUnits[string(openmeteo.Temperature2mMax)].(string)
+		s += label().Render("\n"+i18n.T("Last year")) + formatRange(minLast, okMinLast, maxLast, okMaxLast, unit)
+		s += label().Render("\n"+i18n.T("Today (forecast)")) + formatRange(minToday, okMin, maxToday, okMax, unit)
+		if okMax && okMaxLast {
+			s += theme.Current().Subtle.Render(i18n.Sprintf("  %+.1f %s", maxToday-maxLast, unit))
+		}
+
+		return s + "\n\n" + m.help.View(m.keys)
+	case viewError:
+		return m.failure.View()
+	default:
+		return "\nunknown error state (history)"
+	}
+}
+
+func (m Model) dayRow(res openmeteo.ArchiveResponse, i int, date string) string {
+	conditions := "-"
+	if code, ok := openmeteo.SeriesValue(res.Daily, string(openmeteo.DailyWeatherCode), i); ok {
+		conditions = fit(i18n.T(openmeteo.MapWeatherCode(code)), CONDITIONS_WIDTH)
+	}
+	row := dateCell.Render(date) + wide.Render(conditions)
+	for _, v := range []openmeteo.DailyWeatherVariables{openmeteo.Temperature2mMin, openmeteo.Temperature2mMax, openmeteo.PrecipitationSum} {
+		value, ok := openmeteo.SeriesValue(res.Daily, string(v), i)
+		if !ok {
+			row += cell.Render("-")
+			continue
+		}
+		row += cell.Render(i18n.Sprintf("%.1f %s", value, res.DailyUnits[string(v)]))
+	}
+	return row
+}
+
+// Cut plain text to fit a column, keeping a space before the next one.
+func fit(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) < width {
+		return text
+	}
+	return string(runes[:width-2]) + "…"
+}
+
+func formatRange(low float64, okLow bool, high float64, okHigh bool, unit string) string {
+	if !okLow || !okHigh {
+		return "-"
+	}
+	return i18n.Sprintf("%.1f / %.1f %s", low, high, unit)
+}
+
+// Today in the timezone of the location, at midnight so days compare equal.
+// Taken from the first day of the forecast, which the API starts at the
+// location's today, or else from the clock in the forecast's timezone.
+func localToday(forecast openmeteo.ForecastResponse) time.Time {
+	if times := openmeteo.SeriesTimes(forecast.Daily); len(times) > 0 {
+		if date, err := time.Parse(time.DateOnly, times[0]); err == nil {
+			return date
+		}
+	}
+	y, mo, d := time.Now().In(forecast.Location()).Date()
+	return time.Date(y, mo, d, 0, 0, 0, 0, time.UTC)
+}
+
+// The forecast of the location is used to compare today with last year.
+func New(location openmeteo.GeocodingResult, today openmeteo.ForecastResponse, cfg config.Config) Model {
+	ellipsis := spinner.New()
+	ellipsis.Spinner = spinner.Ellipsis
+	ellipsis.Style = theme.Current().Accent
+
+	// The window starts at yesterday, the latest day that can be in the archive
+	date := localToday(today)
+	return Model{
+		view:     viewLoading,
+		ellipsis: ellipsis,
+		cfg:      cfg,
+		location: location,
+		today:    today,
+		date:     date,
+		end:      date.AddDate(0, 0, -1),
+		keys:     newKeyMap(),
+		help:     theme.Help(),
+	}
+}
@@ -0,0 +1,182 @@
+package icons
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/esferadigital/clima/internal/openmeteo"
+)
+
+// How icons are drawn. Emoji and Nerd Font icons are a single glyph,
+// ASCII icons are a block of several lines.
+type Style string
+
+const (
+	StyleASCII Style = "ascii"
+	StyleEmoji Style = "emoji"
+	StyleNerd  Style = "nerd"
+)
+
+func ParseStyle(s string) (Style, error) {
+	switch Style(s) {
+	case "", StyleASCII:
+		return StyleASCII, nil
+	case StyleEmoji, StyleNerd:
+		return Style(s), nil
+	default:
+		return "", fmt.Errorf("unknown icon style %q: use ascii, emoji or nerd", s)
+	}
+}
+
+// Icon for a WMO weather code, by day or night.
+// Unknown codes get a generic icon.
+func For(code float64, isDay bool, style Style) string {
+	k := openmeteo.CategoryOf(code)
+	switch style {
+	case StyleEmoji:
+		return pick(emoji, k, isDay)
+	case StyleNerd:
+		return pick(nerd, k, isDay)
+	default:
+		return strings.Join(pickArt(k, isDay), "\n")
+	}
+}
+
+// Glyphs by weather category, with an optional night variant.
+type glyph struct {
+	day   string
+	night string
+}
+
+func pick(glyphs map[openmeteo.Category]glyph, k openmeteo.Category, isDay bool) string {
+	g, ok := glyphs[k]
+	if !ok {
+		g = glyphs[openmeteo.CategoryUnknown]
+	}
+	if !isDay && g.night != "" {
+		return g.night
+	}
+	return g.day
+}
+
+var emoji = map[openmeteo.Category]glyph{
+	openmeteo.CategoryUnknown:      {day: "❔"},
+	openmeteo.CategoryClear:        {day: "☀️", night: "🌙"},
+	openmeteo.CategoryPartlyCloudy: {day: "⛅", night: "☁️"},
+	openmeteo.CategoryOvercast:     {day: "☁️"},
+	openmeteo.CategoryFog:          {day: "🌫️"},
+	openmeteo.CategoryDrizzle:      {day: "🌦️", night: "🌧️"},
+	openmeteo.CategoryRain:         {day: "🌧️"},
+	openmeteo.CategorySnow:         {day: "❄️"},
+	openmeteo.CategoryThunderstorm: {day: "⛈️"},
+}
+
+// Weather Icons glyphs bundled with Nerd Fonts.
+// https://www.nerdfonts.com/cheat-sheet
+var nerd = map[openmeteo.Category]glyph{
+	openmeteo.CategoryUnknown:      {day: "\ue374"},
+	openmeteo.CategoryClear:        {day: "\ue30d", night: "\ue32b"},
+	openmeteo.CategoryPartlyCloudy: {day: "\ue302", night: "\ue37e"},
+	openmeteo.CategoryOvercast:     {day: "\ue312"},
+	openmeteo.CategoryFog:          {day: "\ue313"},
+	openmeteo.CategoryDrizzle:      {day: "\ue31b"},
+	openmeteo.CategoryRain:         {day: "\ue318"},
+	openmeteo.CategorySnow:         {day: "\ue31a"},
+	openmeteo.CategoryThunderstorm: {day: "\ue31d"},
+}
+
+func pickArt(k openmeteo.Category, isDay bool) []string {
+	if !isDay {
+		if lines, ok := nightArt[k]; ok {
+			return lines
+		}
+	}
+	if lines, ok := art[k]; ok {
+		return lines
+	}
+	return art[openmeteo.CategoryUnknown]
+}
+
+// Every block is 5 lines of 13 columns so they line up with the text next to them.
+var art = map[openmeteo.Category][]string{
+	openmeteo.CategoryUnknown: {
+		"    .--.     ",
+		"   '   _)    ",
+		"      /      ",
+		"     |       ",
+		"     o       ",
+	},
+	openmeteo.CategoryClear: {
+		"    \\   /    ",
+		"     .-.     ",
+		"  - (   ) -  ",
+		"     '-'     ",
+		"    /   \\    ",
+	},
+	openmeteo.CategoryPartlyCloudy: {
+		"   \\  /      ",
+		" _ /\"\".-.    ",
+		"   \\_(   ).  ",
+		"   /(___(__) ",
+		"             ",
+	},
+	openmeteo.CategoryOvercast: {
+		"             ",
+		"     .--.    ",
+		"  .-(    ).  ",
+		" (___.__)__) ",
+		"             ",
+	},
+	openmeteo.CategoryFog: {
+		"             ",
+		" _ - _ - _ - ",
+		"  _ - _ - _  ",
+		" _ - _ - _ - ",
+		"             ",
+	},
+	openmeteo.CategoryDrizzle: {
+		"     .-.     ",
+		"    (   ).   ",
+		"   (___(__)  ",
+		"    ' ' ' '  ",
+		"   ' ' ' '   ",
+	},
+	openmeteo.CategoryRain: {
+		"     .-.     ",
+		"    (   ).   ",
+		"   (___(__)  ",
+		"   / / / /   ",
+		"  / / / /    ",
+	},
+	openmeteo.CategorySnow: {
+		"     .-.     ",
+		"    (   ).   ",
+		"   (___(__)  ",
+		"    *  *  *  ",
+		"   *  *  *   ",
+	},
+	openmeteo.CategoryThunderstorm: {
+		"     .-.     ",
+		"    (   ).   ",
+		"   (___(__)  ",
+		"    /_  /_   ",
+		"     /   /   ",
+	},
+}
+
+var nightArt = map[openmeteo.Category][]string{
+	openmeteo.CategoryClear: {
+		"     .--.    ",
+		"    /  .'    ",
+		"   |  (      ",
+		"    \\  '.    ",
+		"     '--'    ",
+	},
+	openmeteo.CategoryPartlyCloudy: {
+		"    .--.     ",
+		"   (  .-.    ",
+		"    '(   ).  ",
+		"    (___(__) ",
+		"             ",
+	},
+}
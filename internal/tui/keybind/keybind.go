@@ -0,0 +1,219 @@
+package keybind
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// Something a key does, as named in the config.
+type Action string
+
+const (
+	Up         Action = "up"
+	Down       Action = "down"
+	Pick       Action = "pick"
+	Search     Action = "search"
+	ExitSearch Action = "exit_search"
+	NewSearch  Action = "new_search"
+	Recent     Action = "recent"
+	Refresh    Action = "refresh"
+	Compare    Action = "compare"
+	History    Action = "history"
+	NextTab    Action = "next_tab"
+	Earlier    Action = "earlier"
+	Later      Action = "later"
+	Retry      Action = "retry"
+	Back       Action = "back"
+	Quit       Action = "quit"
+	Rename     Action = "rename"
+	Delete     Action = "delete"
+	Clear      Action = "clear"
+	MoveUp     Action = "move_up"
+	MoveDown   Action = "move_down"
+	Confirm    Action = "confirm"
+	Cancel     Action = "cancel"
+	Filter     Action = "filter"
+	PrevPage   Action = "prev_page"
+	NextPage   Action = "next_page"
+	GoToStart  Action = "go_to_start"
+	GoToEnd    Action = "go_to_end"
+)
+
+// Key names are the ones Bubble Tea reports, e.g. "up", "esc" or "ctrl+r".
+type Keys map[Action][]string
+
+var defaults = Keys{
+	Up:         {"up"},
+	Down:       {"down"},
+	Pick:       {"enter"},
+	Search:     {"enter"},
+	ExitSearch: {"esc"},
+	NewSearch:  {"n"},
+	Recent:     {"b"},
+	Refresh:    {"r"},
+	Compare:    {"c"},
+	History:    {"h"},
+	NextTab:    {"tab"},
+	Earlier:    {"["},
+	Later:      {"]"},
+	Retry:      {"r"},
+	Back:       {"esc", "backspace"},
+	Quit:       {"q"},
+	Rename:     {"a"},
+	Delete:     {"d"},
+	Clear:      {"D"},
+	MoveUp:     {"shift+up"},
+	MoveDown:   {"shift+down"},
+	Confirm:    {"y"},
+	Cancel:     {"n", "esc"},
+	Filter:     {"/"},
+	PrevPage:   {"pgup"},
+	NextPage:   {"pgdown"},
+	GoToStart:  {"home"},
+	GoToEnd:    {"end"},
+}
+
+// Presets change a few actions on top of the defaults.
+var presets = map[string]Keys{
+	"arrows": {},
+	"vim": {
+		Up:        {"k", "up"},
+		Down:      {"j", "down"},
+		NextTab:   {"tab", "l"},
+		Earlier:   {"[", "H"},
+		Later:     {"]", "L"},
+		MoveUp:    {"K", "shift+up"},
+		MoveDown:  {"J", "shift+down"},
+		PrevPage:  {"pgup", "ctrl+b"},
+		NextPage:  {"pgdown", "ctrl+f"},
+		GoToStart: {"home", "g"},
+		GoToEnd:   {"end", "G"},
+	},
+}
+
+// Actions available together on each screen. A key may only do one of them.
+var scopes = map[string][]Action{
+	"recent":       {Up, Down, PrevPage, NextPage, GoToStart, GoToEnd, Pick, NewSearch, Rename, Delete, Clear, MoveUp, MoveDown, Filter, Back, Quit},
+	"confirm":      {Confirm, Cancel},
+	"search input": {Search, ExitSearch},
+	"search list":  {Up, Down, PrevPage, NextPage, GoToStart, GoToEnd, Pick, NewSearch, Filter, Back, Quit},
+	"weather":      {NextTab, NewSearch, Recent, Refresh, Compare, History, Back, Quit},
+	"compare":      {Back, Refresh, Quit},
+	"history":      {Earlier, Later, Back, Quit},
+	"error":        {Retry, Back, Quit},
+}
+
+// Build the keys from a preset and the overrides from the config.
+// Fails on unknown presets or actions, and on keys that do two things on the same screen.
+func Load(preset string, overrides map[string][]string) (Keys, error) {
+	if preset == "" {
+		preset = "arrows"
+	}
+	changes, ok := presets[preset]
+	if !ok {
+		return nil, fmt.Errorf("unknown key preset %q: use arrows or vim", preset)
+	}
+
+	keys := Keys{}
+	for action, bound := range defaults {
+		keys[action] = bound
+	}
+	for action, bound := range changes {
+		keys[action] = bound
+	}
+	for name, bound := range overrides {
+		action := Action(name)
+		if _, ok := defaults[action]; !ok {
+			return nil, fmt.Errorf("unknown key action %q", name)
+		}
+		if len(bound) == 0 {
+			return nil, fmt.Errorf("no keys for action %q", name)
+		}
+		keys[action] = bound
+	}
+
+	if err := keys.conflicts(); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (k Keys) conflicts() error {
+	var problems []string
+	for scope, actions := range scopes {
+		owners := map[string]Action{}
+		for _, action := range actions {
+			for _, bound := range k[action] {
+				if owner, ok := owners[bound]; ok && owner != action {
+					problems = append(problems, fmt.Sprintf("%q is bound to both %s and %s in %s", bound, owner, action, scope))
+					continue
+				}
+				owners[bound] = action
+			}
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return fmt.Errorf("conflicting keys: %s", strings.Join(problems, "; "))
+}
+
+var current = defaults
+
+// Set the keys used by every screen from now on.
+// Called once at startup, before any model is built.
+func Set(k Keys) {
+	current = k
+}
+
+// Binding for an action with its active keys, described for the help.
+// The help shows the first key, so it always matches what works.
+func Binding(action Action, help string) key.Binding {
+	bound := current[action]
+	return key.NewBinding(
+		key.WithKeys(bound...),
+		key.WithHelp(display(bound), help),
+	)
+}
+
+// Symbols for keys that read better as one.
+var symbols = map[string]string{
+	"up":    "↑",
+	"down":  "↓",
+	"left":  "←",
+	"right": "→",
+}
+
+func display(bound []string) string {
+	if len(bound) == 0 {
+		return ""
+	}
+	if symbol, ok := symbols[bound[0]]; ok {
+		return symbol
+	}
+	return bound[0]
+}
+
+// List keys moving the cursor and through pages with the active keys,
+// and filtering with the filter key. The list's own defaults, like b, d, h, l
+// and g, are left out so they cannot clash unseen with those of the screens.
+// Quitting is left to the screens.
+func ListKeyMap() list.KeyMap {
+	keys := list.DefaultKeyMap()
+	keys.CursorUp = key.NewBinding(key.WithKeys(current[Up]...))
+	keys.CursorDown = key.NewBinding(key.WithKeys(current[Down]...))
+	keys.PrevPage = key.NewBinding(key.WithKeys(current[PrevPage]...))
+	keys.NextPage = key.NewBinding(key.WithKeys(current[NextPage]...))
+	keys.GoToStart = key.NewBinding(key.WithKeys(current[GoToStart]...))
+	keys.GoToEnd = key.NewBinding(key.WithKeys(current[GoToEnd]...))
+	keys.Filter = key.NewBinding(key.WithKeys(current[Filter]...))
+	keys.Quit.SetEnabled(false)
+	keys.ShowFullHelp.SetEnabled(false)
+	keys.CloseFullHelp.SetEnabled(false)
+	return keys
+}
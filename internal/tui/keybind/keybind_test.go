@@ -0,0 +1,83 @@
+package keybind
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name      string
+		preset    string
+		overrides map[string][]string
+		want      map[Action][]string
+		err       string
+	}{
+		{name: "defaults", want: map[Action][]string{Up: {"up"}, Quit: {"q"}}},
+		{name: "vim preset keeps the arrows", preset: "vim", want: map[Action][]string{Up: {"k", "up"}, GoToEnd: {"end", "G"}}},
+		{
+			name:      "overrides replace the preset",
+			preset:    "vim",
+			overrides: map[string][]string{"up": {"ctrl+p"}},
+			want:      map[Action][]string{Up: {"ctrl+p"}, Down: {"j", "down"}},
+		},
+		{
+			name:      "same key for actions on different screens",
+			overrides: map[string][]string{"history": {"y"}},
+			want:      map[Action][]string{History: {"y"}, Confirm: {"y"}},
+		},
+		{
+			name:      "same key twice for one action",
+			overrides: map[string][]string{"quit": {"q", "q"}},
+			want:      map[Action][]string{Quit: {"q", "q"}},
+		},
+		{name: "unknown preset", preset: "emacs", err: `unknown key preset "emacs"`},
+		{name: "unknown action", overrides: map[string][]string{"jump": {"j"}}, err: `unknown key action "jump"`},
+		{name: "no keys", overrides: map[string][]string{"quit": {}}, err: `no keys for action "quit"`},
+		{
+			name:      "conflict on one screen",
+			overrides: map[string][]string{"refresh": {"n"}},
+			err:       `"n" is bound to both`,
+		},
+		{
+			name:      "conflict with a preset key",
+			preset:    "vim",
+			overrides: map[string][]string{"new_search": {"j"}},
+			err:       "in recent",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := Load(tt.preset, tt.overrides)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("err = %v, want one containing %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for action, want := range tt.want {
+				if got := keys[action]; !reflect.DeepEqual(got, want) {
+					t.Errorf("%s = %q, want %q", action, got, want)
+				}
+			}
+		})
+	}
+}
+
+// Loading must not change the defaults or presets shared by later loads.
+func TestLoadCopies(t *testing.T) {
+	if _, err := Load("vim", map[string][]string{"quit": {"x"}}); err != nil {
+		t.Fatal(err)
+	}
+	if got := defaults[Quit]; !reflect.DeepEqual(got, []string{"q"}) {
+		t.Errorf("default quit = %q after an override, want [q]", got)
+	}
+	if _, ok := presets["vim"][Quit]; ok {
+		t.Error("vim preset gained quit after an override")
+	}
+}
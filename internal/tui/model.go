@@ -3,11 +3,16 @@ package tui
 import (
 	"fmt"
 	"io"
+	"slices"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/esferadigital/clima/internal/config"
 	"github.com/esferadigital/clima/internal/openmeteo"
+	"github.com/esferadigital/clima/internal/tui/compare"
+	"github.com/esferadigital/clima/internal/tui/history"
+	"github.com/esferadigital/clima/internal/tui/nav"
 	"github.com/esferadigital/clima/internal/tui/recent"
 	"github.com/esferadigital/clima/internal/tui/search"
 	"github.com/esferadigital/clima/internal/tui/weather"
@@ -21,17 +26,99 @@ const (
 	routeRecent = iota
 	routeSearch
 	routeWeather
+	routeCompare
+	routeHistory
 )
 
+// Screens keep their state while they are in the stack,
+// so going back returns to them as they were left.
+// A route is in the stack at most once.
 type Model struct {
 	sink    io.Writer
-	route   route
+	cfg     config.Config
+	stack   []route
 	recent  recent.Model
 	search  search.Model
 	weather weather.Model
+	compare compare.Model
+	history history.Model
+}
+
+// Screen on top of the stack.
+func (m Model) route() route {
+	return m.stack[len(m.stack)-1]
+}
+
+// Open a screen with fresh state, whose model must be set beforehand.
+// If the route is already in the stack, the screens above it are dropped first.
+func (m Model) push(r route, init tea.Cmd) (Model, tea.Cmd) {
+	m.stack = append(slices.Clone(m.drop(r)), r)
+	return m, init
+}
+
+// Swap the screen on top for another one with fresh state.
+func (m Model) replace(r route, init tea.Cmd) (Model, tea.Cmd) {
+	m.stack = m.stack[:len(m.stack)-1]
+	return m.push(r, init)
+}
+
+// Go back to the previous screen. The first screen stays.
+func (m Model) pop() (Model, tea.Cmd) {
+	if len(m.stack) == 1 {
+		return m, nil
+	}
+	m.stack = m.stack[:len(m.stack)-1]
+	return m.resume()
+}
+
+// Go back to a screen in the stack, or open it fresh when it is not there.
+func (m Model) popTo(r route, fresh func() (Model, tea.Cmd)) (Model, tea.Cmd) {
+	for i, entry := range m.stack {
+		if entry == r {
+			m.stack = m.stack[:i+1]
+			return m.resume()
+		}
+	}
+	return fresh()
+}
+
+// Stack without the route and the screens above it.
+func (m Model) drop(r route) []route {
+	for i, entry := range m.stack {
+		if entry == r {
+			return m.stack[:i]
+		}
+	}
+	return m.stack
+}
+
+// Wake the screen on top after coming back to it.
+func (m Model) resume() (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	switch m.route() {
+	case routeRecent:
+		// Reload, the list may have changed while away
+		cmd = m.recent.Init()
+	case routeWeather:
+		m.weather, cmd = m.weather.Resume()
+	}
+	return m, cmd
+}
+
+func (m Model) openWeather(location openmeteo.GeocodingResult) (Model, tea.Cmd) {
+	m.weather = weather.New(location, m.cfg)
+	return m.push(routeWeather, m.weather.Init())
+}
+
+func (m Model) openSearch() (Model, tea.Cmd) {
+	m.search = search.New()
+	return m.push(routeSearch, m.search.Init())
 }
 
 func (m Model) Init() tea.Cmd {
+	if m.route() == routeWeather {
+		return m.weather.Init()
+	}
 	return m.recent.Init()
 }
 
@@ -45,42 +132,63 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// messages from sub-components
 	switch msg := msg.(type) {
 
+	// focus pauses the weather refresh even while another route is shown,
+	// commands are dropped since Resume restarts the timer
+	case tea.FocusMsg, tea.BlurMsg:
+		if m.route() != routeWeather {
+			m.weather, _ = m.weather.Update(msg)
+		}
+
+	// previews fetched in the background land in the list even when it is hidden
+	case recent.PreviewMsg:
+		if m.route() != routeRecent {
+			var cmd tea.Cmd
+			m.recent, cmd = m.recent.Update(msg)
+			return m, cmd
+		}
+
+	// any screen
+	case nav.BackMsg:
+		// Search is only first when there were no recent locations to show
+		if len(m.stack) == 1 && m.route() == routeSearch {
+			m.recent = recent.New(m.cfg, false)
+			return m.replace(routeRecent, m.recent.Init())
+		}
+		return m.pop()
+
 	// recent
 	case recent.RecentCompleteMsg:
 		if !msg.OK {
-			m.route = routeSearch
-			return m, m.search.Init()
+			m.search = search.New()
+			return m.replace(routeSearch, m.search.Init())
 		}
-		m.route = routeWeather
-		m.weather = weather.New(msg.Location)
-		return m, m.weather.Init()
+		return m.openWeather(msg.Location)
 	case recent.NewSearchMsg:
-		m.route = routeSearch
-		return m, m.search.Init()
+		return m.openSearch()
 
 	// search
 	case search.SearchCompleteMsg:
-		m.route = routeWeather
-		m.weather = weather.New(msg.Location)
-		return m, m.weather.Init()
-	case search.RecentMsg:
-		m.route = routeRecent
-		m.recent = recent.New()
-		return m, m.recent.Init()
+		return m.openWeather(msg.Location)
 
 	// weather
-	// @todo: reset search, calling Init() does not reset the state
 	case weather.NewSearchMsg:
-		m.route = routeSearch
-		return m, m.search.Init()
+		return m.openSearch()
 	case weather.RecentMsg:
-		m.route = routeRecent
-		return m, m.recent.Init()
+		return m.popTo(routeRecent, func() (Model, tea.Cmd) {
+			m.recent = recent.New(m.cfg, false)
+			return m.push(routeRecent, m.recent.Init())
+		})
+	case weather.CompareMsg:
+		m.compare = compare.New(msg.Location, m.cfg)
+		return m.push(routeCompare, m.compare.Init())
+	case weather.HistoryMsg:
+		m.history = history.New(msg.Location, msg.Forecast, m.cfg)
+		return m.push(routeHistory, m.history.Init())
 	}
 
 	// Forward updates to sub-components
 	var cmd tea.Cmd
-	switch m.route {
+	switch m.route() {
 	case routeRecent:
 		m.recent, cmd = m.recent.Update(msg)
 		return m, cmd
@@ -90,29 +198,53 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case routeWeather:
 		m.weather, cmd = m.weather.Update(msg)
 		return m, cmd
+	case routeCompare:
+		m.compare, cmd = m.compare.Update(msg)
+		return m, cmd
+	case routeHistory:
+		m.history, cmd = m.history.Update(msg)
+		return m, cmd
 	default:
 		return m, nil
 	}
 }
 
 func (m Model) View() string {
-	switch m.route {
+	switch m.route() {
 	case routeRecent:
 		return m.recent.View()
 	case routeSearch:
 		return m.search.View()
 	case routeWeather:
 		return m.weather.View()
+	case routeCompare:
+		return m.compare.View()
+	case routeHistory:
+		return m.history.View()
 	default:
 		return "Unknown state (core)"
 	}
 }
 
-func InitialModel(sink io.Writer) Model {
+// Root model. With a start location its forecast opens right away,
+// with the recent list behind it, otherwise the recent list is first.
+func InitialModel(sink io.Writer, cfg config.Config, start *openmeteo.GeocodingResult) Model {
+	if start != nil {
+		return Model{
+			sink:    sink,
+			cfg:     cfg,
+			stack:   []route{routeRecent, routeWeather},
+			recent:  recent.New(cfg, false),
+			search:  search.New(),
+			weather: weather.New(*start, cfg),
+		}
+	}
 	return Model{
 		sink:    sink,
-		recent:  recent.New(),
+		cfg:     cfg,
+		stack:   []route{routeRecent},
+		recent:  recent.New(cfg, true),
 		search:  search.New(),
-		weather: weather.New(openmeteo.GeocodingResult{}),
+		weather: weather.New(openmeteo.GeocodingResult{}, cfg),
 	}
 }
@@ -0,0 +1,13 @@
+package nav
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// Asks the root model to go back to the previous screen,
+// which comes back with the state it was left in.
+type BackMsg struct{}
+
+func Back() tea.Cmd {
+	return func() tea.Msg {
+		return BackMsg{}
+	}
+}
@@ -0,0 +1,90 @@
+package picker
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/sahilm/fuzzy"
+
+	"github.com/esferadigital/clima/internal/tui/keybind"
+	"github.com/esferadigital/clima/internal/tui/theme"
+)
+
+// Location list, set up the same way on every screen that picks one.
+// Items are filtered with `/`, see Filter.
+func New(width int, height int) list.Model {
+	l := list.New([]list.Item{}, theme.ListDelegate(), width, height)
+	l.SetShowStatusBar(false)
+	l.SetShowHelp(false)
+	l.SetShowTitle(false)
+	l.SetFilteringEnabled(true)
+	l.Filter = Filter
+	l.FilterInput.Prompt = "/ "
+	l.FilterInput.PromptStyle = theme.Current().Accent
+	l.FilterInput.Cursor.Style = theme.Current().Accent
+	l.KeyMap = keybind.ListKeyMap()
+	return l
+}
+
+// Whether a key belongs to the filter of the list rather than to the screen:
+// everything while typing the filter, and clearing an applied one.
+func HandlesKey(l list.Model, msg tea.KeyMsg) bool {
+	switch l.FilterState() {
+	case list.Filtering:
+		return true
+	case list.FilterApplied:
+		return key.Matches(msg, l.KeyMap.ClearFilter)
+	default:
+		return false
+	}
+}
+
+// Fuzzy filter that ignores accents, so "neuquen" finds "Neuquén".
+// Matched positions are given in runes, as the delegate highlights them.
+// Items put their title first in FilterValue so the highlight lands on it.
+func Filter(term string, targets []string) []list.Rank {
+	folded := make([]string, len(targets))
+	for i, target := range targets {
+		folded[i] = fold(target)
+	}
+
+	matches := fuzzy.Find(fold(term), folded)
+	sort.Stable(matches)
+
+	ranks := make([]list.Rank, len(matches))
+	for i, match := range matches {
+		ranks[i] = list.Rank{
+			Index:          match.Index,
+			MatchedIndexes: runeIndexes(match.Str, match.MatchedIndexes),
+		}
+	}
+	return ranks
+}
+
+// Letters with diacritics and their plain form. Each rune maps to a single
+// rune so positions in the folded text match the original.
+var plain = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ä", "a", "ã", "a", "å", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "ö", "o", "õ", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ñ", "n", "ç", "c", "ý", "y", "ÿ", "y",
+)
+
+func fold(s string) string {
+	return plain.Replace(strings.ToLower(s))
+}
+
+// Convert byte offsets, as the fuzzy package reports them, to rune offsets.
+func runeIndexes(s string, byteIndexes []int) []int {
+	runes := make([]int, len(byteIndexes))
+	for i, b := range byteIndexes {
+		runes[i] = utf8.RuneCountInString(s[:b])
+	}
+	return runes
+}
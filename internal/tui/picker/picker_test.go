@@ -0,0 +1,66 @@
+package picker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Neuquén", "neuquen"},
+		{"São Paulo", "sao paulo"},
+		{"Zürich", "zurich"},
+		{"A Coruña", "a coruna"},
+		// Letters without a plain form are only lowercased
+		{"Ærø", "ærø"},
+	}
+
+	for _, tt := range tests {
+		if got := fold(tt.text); got != tt.want {
+			t.Errorf("fold(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	targets := []string{
+		"Neuquén, Argentina",
+		"Łódź, Poland",
+		"Quito, Ecuador",
+		"São Paulo, Brazil",
+	}
+
+	tests := []struct {
+		name    string
+		term    string
+		index   int
+		matched []int
+	}{
+		{"plain term finds accented name", "neuquen", 0, []int{0, 1, 2, 3, 4, 5, 6}},
+		{"accented term finds the same", "NEUQUÉN", 0, []int{0, 1, 2, 3, 4, 5, 6}},
+		{"positions count runes, not bytes", "od", 1, []int{1, 2}},
+		{"letters may be apart", "spl", 3, []int{0, 4, 7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranks := Filter(tt.term, targets)
+			if len(ranks) == 0 {
+				t.Fatalf("no match for %q", tt.term)
+			}
+			if ranks[0].Index != tt.index {
+				t.Fatalf("best match = %q, want %q", targets[ranks[0].Index], targets[tt.index])
+			}
+			if !reflect.DeepEqual(ranks[0].MatchedIndexes, tt.matched) {
+				t.Errorf("matched = %v, want %v", ranks[0].MatchedIndexes, tt.matched)
+			}
+		})
+	}
+
+	if ranks := Filter("xyz", targets); len(ranks) != 0 {
+		t.Errorf("Filter(xyz) = %v, want no match", ranks)
+	}
+}
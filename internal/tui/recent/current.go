@@ -0,0 +1,43 @@
+package recent
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/esferadigital/clima/internal/i18n"
+	"github.com/esferadigital/clima/internal/location"
+	"github.com/esferadigital/clima/internal/openmeteo"
+)
+
+// ---- msg ----
+
+type locatedMsg struct {
+	location openmeteo.GeocodingResult
+	err      error
+}
+
+// ---- helpers ----
+
+// Entry at the top of the list that opens the current position.
+// Only shown when the config has a position source.
+type currentLocationItem struct{}
+
+func (i currentLocationItem) FilterValue() string {
+	return i.Title()
+}
+
+func (i currentLocationItem) Title() string {
+	return i18n.T("Current location")
+}
+
+func (i currentLocationItem) Description() string {
+	return i18n.T("from GPS, IP or the configured position")
+}
+
+// ---- cmd ----
+
+func locateCmd(sources location.Sources) tea.Cmd {
+	return func() tea.Msg {
+		loc, err := sources.Locate()
+		return locatedMsg{location: loc, err: err}
+	}
+}
@@ -0,0 +1,130 @@
+package recent
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/esferadigital/clima/internal/config"
+	"github.com/esferadigital/clima/internal/forecast"
+	"github.com/esferadigital/clima/internal/i18n"
+	"github.com/esferadigital/clima/internal/openmeteo"
+	"github.com/esferadigital/clima/internal/store"
+)
+
+// Forecasts fetched at the same time for previews, so opening the list
+// does not send a burst of requests.
+const PREVIEW_WORKERS = 2
+
+// Last known conditions of a recent location.
+type preview struct {
+	cached forecast.Cached
+	// Age from which the conditions are shown as old
+	ttl time.Duration
+	// Fetching a newer forecast in the background
+	refreshing bool
+	// No forecast could be fetched nor found in the cache
+	failed bool
+}
+
+// ---- msg ----
+
+// Forecast for a preview, from the cache or just fetched.
+// Delivered even while another screen is shown, the list keeps it.
+type PreviewMsg struct {
+	id      int
+	cached  forecast.Cached
+	fetched bool
+	err     error
+}
+
+// ---- cmd ----
+
+func loadPreviewCmd(cfg config.Config, location store.Location) tea.Cmd {
+	return func() tea.Msg {
+		cached, err := forecast.Load(cfg, location.Latitude, location.Longitude)
+		return PreviewMsg{id: location.ID, cached: cached, err: err}
+	}
+}
+
+// Fetch a forecast once one of the workers is free.
+func refreshPreviewCmd(cfg config.Config, workers chan struct{}, location store.Location) tea.Cmd {
+	return func() tea.Msg {
+		workers <- struct{}{}
+		defer func() { <-workers }()
+
+		res, source, err := forecast.Fetch(cfg, location.GeocodingResult)
+		cached := forecast.Cached{FetchedAt: time.Now(), Provider: source.Name, Forecast: res}
+		return PreviewMsg{id: location.ID, cached: cached, fetched: true, err: err}
+	}
+}
+
+// ---- model ----
+
+// Show the conditions from the cache, and fetch them again once they are stale.
+func (m Model) updatePreview(msg PreviewMsg) (Model, tea.Cmd) {
+	index := -1
+	for i, item := range m.list.Items() {
+		if recent, ok := item.(recentLocationItem); ok && recent.ID == msg.id {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return m, nil
+	}
+	item := m.list.Items()[index].(recentLocationItem)
+
+	var cmd tea.Cmd
+	p := m.previews[msg.id]
+	p.ttl = forecast.TTL(m.cfg)
+	switch {
+	case msg.fetched:
+		p.refreshing = false
+		if msg.err == nil {
+			p.cached = msg.cached
+		}
+		p.failed = p.cached.FetchedAt.IsZero()
+	case msg.err == nil && msg.cached.FetchedAt.After(p.cached.FetchedAt):
+		p.cached = msg.cached
+	}
+	if !msg.fetched && !p.refreshing && !p.cached.Fresh(m.cfg) {
+		p.refreshing = true
+		cmd = refreshPreviewCmd(m.cfg, m.workers, item.Location)
+	}
+	m.previews[msg.id] = p
+
+	item.preview = p
+	return m, tea.Batch(m.list.SetItem(index, item), cmd)
+}
+
+// ---- view ----
+
+// Current temperature and condition, e.g. "18.2 °C, Partly cloudy".
+// Older data says how old it is.
+func (p preview) View() string {
+	current := p.cached.Forecast.Current
+	temperature, ok := current[string(openmeteo.Temperature2m)].(float64)
+	if !ok {
+		if p.failed {
+			return i18n.T("no forecast available")
+		}
+		return "…"
+	}
+
+	unit, _ := p.cached.Forecast.CurrentUnits[string(openmeteo.Temperature2m)].(string)
+	s := i18n.Sprintf("%.1f %s", temperature, unit)
+	if code, ok := current[string(openmeteo.WeatherCode)].(float64); ok {
+		s += ", " + i18n.T(openmeteo.MapWeatherCode(code))
+	}
+	age := time.Since(p.cached.FetchedAt)
+	if age < p.ttl {
+		return s
+	}
+
+	minutes := int(age.Minutes())
+	if minutes < 60 {
+		return s + " (" + i18n.T("updated %d min ago", minutes) + ")"
+	}
+	return s + " (" + i18n.T("updated %d h ago", minutes/60) + ")"
+}
@@ -1,15 +1,25 @@
 package recent
 
 import (
-	"fmt"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/esferadigital/clima/internal/config"
+	"github.com/esferadigital/clima/internal/i18n"
+	"github.com/esferadigital/clima/internal/location"
 	"github.com/esferadigital/clima/internal/openmeteo"
 	"github.com/esferadigital/clima/internal/store"
+	"github.com/esferadigital/clima/internal/tui/errorview"
+	"github.com/esferadigital/clima/internal/tui/keybind"
+	"github.com/esferadigital/clima/internal/tui/nav"
+	"github.com/esferadigital/clima/internal/tui/picker"
+	"github.com/esferadigital/clima/internal/tui/theme"
 )
 
 // ---- keymap ----
@@ -19,11 +29,18 @@ type keyMap struct {
 	down      key.Binding
 	pick      key.Binding
 	newSearch key.Binding
+	rename    key.Binding
+	delete    key.Binding
+	moveUp    key.Binding
+	moveDown  key.Binding
+	clear     key.Binding
+	filter    key.Binding
+	back      key.Binding
 	quit      key.Binding
 }
 
 func (k keyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.up, k.down, k.pick, k.newSearch, k.quit}
+	return []key.Binding{k.up, k.down, k.pick, k.newSearch, k.rename, k.delete, k.moveUp, k.moveDown, k.clear, k.filter, k.back, k.quit}
 }
 
 func (k keyMap) FullHelp() [][]key.Binding {
@@ -32,45 +49,96 @@ func (k keyMap) FullHelp() [][]key.Binding {
 		{k.down},
 		{k.pick},
 		{k.newSearch},
+		{k.rename},
+		{k.delete},
+		{k.moveUp},
+		{k.moveDown},
+		{k.clear},
+		{k.filter},
+		{k.back},
 		{k.quit},
 	}
 }
 
 func newKeyMap() keyMap {
 	return keyMap{
-		up: key.NewBinding(
-			key.WithKeys("↑"),
-			key.WithHelp("↑", "up"),
-		),
-		down: key.NewBinding(
-			key.WithKeys("↓"),
-			key.WithHelp("↓", "down"),
-		),
-		pick: key.NewBinding(
-			key.WithKeys("enter"),
-			key.WithHelp("enter", "pick"),
-		),
-		newSearch: key.NewBinding(
-			key.WithKeys("n"),
-			key.WithHelp("n", "new search"),
-		),
-		quit: key.NewBinding(
-			key.WithKeys("q"),
-			key.WithHelp("q", "quit"),
-		),
+		up:        keybind.Binding(keybind.Up, i18n.T("up")),
+		down:      keybind.Binding(keybind.Down, i18n.T("down")),
+		pick:      keybind.Binding(keybind.Pick, i18n.T("pick")),
+		newSearch: keybind.Binding(keybind.NewSearch, i18n.T("new search")),
+		rename:    keybind.Binding(keybind.Rename, i18n.T("alias")),
+		delete:    keybind.Binding(keybind.Delete, i18n.T("delete")),
+		moveUp:    keybind.Binding(keybind.MoveUp, i18n.T("move up")),
+		moveDown:  keybind.Binding(keybind.MoveDown, i18n.T("move down")),
+		clear:     keybind.Binding(keybind.Clear, i18n.T("clear all")),
+		filter:    keybind.Binding(keybind.Filter, i18n.T("filter")),
+		back:      keybind.Binding(keybind.Back, i18n.T("back")),
+		quit:      keybind.Binding(keybind.Quit, i18n.T("quit")),
+	}
+}
+
+// Keys while typing an alias.
+type renameKeyMap struct {
+	save   key.Binding
+	cancel key.Binding
+}
+
+func (k renameKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.save, k.cancel}
+}
+
+func (k renameKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.save, k.cancel},
+	}
+}
+
+func newRenameKeyMap() renameKeyMap {
+	return renameKeyMap{
+		save:   keybind.Binding(keybind.Search, i18n.T("save")),
+		cancel: keybind.Binding(keybind.ExitSearch, i18n.T("cancel")),
+	}
+}
+
+// Keys while confirming that every location should go.
+type confirmKeyMap struct {
+	confirm key.Binding
+	cancel  key.Binding
+}
+
+func (k confirmKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.confirm, k.cancel}
+}
+
+func (k confirmKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.confirm, k.cancel},
+	}
+}
+
+func newConfirmKeyMap() confirmKeyMap {
+	return confirmKeyMap{
+		confirm: keybind.Binding(keybind.Confirm, i18n.T("clear all")),
+		cancel:  keybind.Binding(keybind.Cancel, i18n.T("cancel")),
 	}
 }
 
 // ---- msg ----
 
 type dataMsg struct {
-	locations []openmeteo.GeocodingResult
+	locations []store.Location
 }
 
 type errorMsg struct {
 	err error
 }
 
+// The recent list was edited. The location at selected gets the cursor.
+type changedMsg struct {
+	selected int
+	err      error
+}
+
 type RecentCompleteMsg struct {
 	Location openmeteo.GeocodingResult
 	OK       bool
@@ -80,21 +148,23 @@ type NewSearchMsg struct{}
 
 // ---- helpers ----
 
-// Implements list.Item interface and wraps location.Location
+// Implements list.Item interface and wraps store.Location
 type recentLocationItem struct {
-	openmeteo.GeocodingResult
+	store.Location
+	preview preview
 }
 
+// Starts with the title, which is where matches are highlighted.
 func (i recentLocationItem) FilterValue() string {
-	return i.Name
+	return strings.Join([]string{i.DisplayName(), i.Name, i.Admin1, i.Country}, " ")
 }
 
 func (i recentLocationItem) Title() string {
-	return fmt.Sprintf("%s, %s", i.Name, i.Country)
+	return i.DisplayName()
 }
 
 func (i recentLocationItem) Description() string {
-	return fmt.Sprintf("Lat: %.4f, Lon: %.4f", i.Latitude, i.Longitude)
+	return i.preview.View()
 }
 
 // ---- cmd ----
@@ -114,6 +184,21 @@ func getRecentLocationsCmd() tea.Cmd {
 	}
 }
 
+// Run an edit of the recent list, then select the location at selected.
+func changeCmd(selected int, change func() error) tea.Cmd {
+	return func() tea.Msg {
+		return changedMsg{selected: selected, err: change()}
+	}
+}
+
+// Move a location, first being the list position of the first recent location.
+func moveCmd(id int, offset int, first int) tea.Cmd {
+	return func() tea.Msg {
+		to, err := store.MoveRecentLocation(id, offset)
+		return changedMsg{selected: first + to, err: err}
+	}
+}
+
 func pickCmd(location openmeteo.GeocodingResult, ok bool) tea.Cmd {
 	return func() tea.Msg {
 		return RecentCompleteMsg{
@@ -135,14 +220,35 @@ type view int
 
 const (
 	viewList = iota
+	viewRename
+	viewConfirmClear
+	viewLocating
 	viewError
 )
 
 type Model struct {
 	view view
-	list list.Model
-	keys keyMap
-	help help.Model
+	cfg  config.Config
+	// Open the only recent location right away, only done on the first load
+	autoPick bool
+	// Cursor position to restore once the list reloads after an edit, -1 if none
+	reselect    int
+	list        list.Model
+	keys        keyMap
+	alias       textinput.Model
+	renameKeys  renameKeyMap
+	confirmKeys confirmKeyMap
+	failure     errorview.Model
+	// The error is about the current position, so retrying locates again
+	locateFailed bool
+	// Where the current position comes from, none hides its entry
+	sources  location.Sources
+	ellipsis spinner.Model
+	help     help.Model
+	// Conditions by location id, kept across reloads of the list
+	previews map[int]preview
+	// Held while fetching a preview, bounds how many run at once
+	workers chan struct{}
 }
 
 func (m Model) Init() tea.Cmd {
@@ -152,68 +258,252 @@ func (m Model) Init() tea.Cmd {
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		if key.Matches(msg, m.keys.pick) {
-			picked, ok := m.list.SelectedItem().(recentLocationItem)
-			if ok {
-				return m, pickCmd(picked.GeocodingResult, true)
+		if m.view == viewError && m.locateFailed {
+			if key.Matches(msg, m.failure.Keys.Retry) {
+				m.view = viewLocating
+				return m, tea.Batch(locateCmd(m.sources), m.ellipsis.Tick)
+			}
+			if key.Matches(msg, m.failure.Keys.Back) {
+				m.view = viewList
+				return m, nil
+			}
+		}
+		if m.view == viewError && !m.locateFailed {
+			if key.Matches(msg, m.failure.Keys.Retry) {
+				m.view = viewList
+				return m, getRecentLocationsCmd()
+			}
+			if key.Matches(msg, m.failure.Keys.Back) {
+				return m, requestNewSearchCmd()
+			}
+		}
+		if m.view == viewError {
+			if key.Matches(msg, m.failure.Keys.Quit) {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+		if m.view == viewLocating {
+			if key.Matches(msg, m.keys.quit) {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+		if m.view == viewRename {
+			return m.updateRename(msg)
+		}
+		if m.view == viewConfirmClear {
+			if key.Matches(msg, m.confirmKeys.confirm) {
+				m.view = viewList
+				return m, changeCmd(0, store.ClearRecentLocations)
+			}
+			if key.Matches(msg, m.confirmKeys.cancel) {
+				m.view = viewList
 			}
+			return m, nil
+		}
+		if picker.HandlesKey(m.list, msg) {
+			var cmd tea.Cmd
+			m.list, cmd = m.list.Update(msg)
+			return m, cmd
+		}
+		if _, current := m.list.SelectedItem().(currentLocationItem); current && key.Matches(msg, m.keys.pick) {
+			m.view = viewLocating
+			return m, tea.Batch(locateCmd(m.sources), m.ellipsis.Tick)
+		}
+		selected, hasSelected := m.list.SelectedItem().(recentLocationItem)
+		if key.Matches(msg, m.keys.pick) && hasSelected {
+			return m, pickCmd(selected.GeocodingResult, true)
+		}
+		if key.Matches(msg, m.keys.rename) && hasSelected {
+			m.view = viewRename
+			m.alias.SetValue(selected.Alias)
+			m.alias.Placeholder = selected.Label()
+			m.alias.CursorEnd()
+			return m, m.alias.Focus()
+		}
+		if key.Matches(msg, m.keys.delete) && hasSelected {
+			return m, changeCmd(m.list.Index(), func() error {
+				return store.RemoveRecentLocation(selected.ID)
+			})
+		}
+		if key.Matches(msg, m.keys.moveUp) && hasSelected {
+			return m, moveCmd(selected.ID, -1, m.first())
+		}
+		if key.Matches(msg, m.keys.moveDown) && hasSelected {
+			return m, moveCmd(selected.ID, 1, m.first())
+		}
+		if key.Matches(msg, m.keys.clear) && len(m.list.Items()) > m.first() {
+			m.view = viewConfirmClear
+			return m, nil
 		}
 		if key.Matches(msg, m.keys.newSearch) {
 			return m, requestNewSearchCmd()
 		}
+		if key.Matches(msg, m.keys.back) {
+			return m, nav.Back()
+		}
 		if key.Matches(msg, m.keys.quit) {
 			return m, tea.Quit
 		}
 	case dataMsg:
-		if len(msg.locations) == 0 {
+		if len(msg.locations) == 0 && len(m.sources) == 0 {
 			return m, pickCmd(openmeteo.GeocodingResult{}, false)
 		}
-		if len(msg.locations) == 1 {
-			return m, pickCmd(msg.locations[0], true)
+		autoPick := m.autoPick
+		m.autoPick = false
+		if len(msg.locations) == 1 && len(m.sources) == 0 && autoPick {
+			return m, pickCmd(msg.locations[0].GeocodingResult, true)
 		}
 
-		items := make([]list.Item, len(msg.locations))
+		items := make([]list.Item, m.first(), m.first()+len(msg.locations))
+		if m.first() > 0 {
+			items[0] = currentLocationItem{}
+		}
+		cmds := make([]tea.Cmd, len(msg.locations))
 		for i, loc := range msg.locations {
-			items[i] = recentLocationItem{loc}
+			items = append(items, recentLocationItem{loc, m.previews[loc.ID]})
+			cmds[i] = loadPreviewCmd(m.cfg, loc)
 		}
 		m.list.SetItems(items)
-		return m, nil
+		if m.reselect >= 0 {
+			m.list.Select(min(m.reselect, len(items)-1))
+			m.reselect = -1
+		}
+		return m, tea.Batch(cmds...)
+	case PreviewMsg:
+		return m.updatePreview(msg)
+	case changedMsg:
+		if msg.err != nil {
+			m.view = viewError
+			m.locateFailed = false
+			m.failure = errorview.New(i18n.T("Failed to update recent locations"), msg.err, errorview.NewKeyMap(i18n.T("new search")))
+			return m, nil
+		}
+		m.reselect = msg.selected
+		return m, getRecentLocationsCmd()
+	case locatedMsg:
+		if msg.err != nil {
+			m.view = viewError
+			m.locateFailed = true
+			m.failure = errorview.New(i18n.T("Failed to find current position"), msg.err, errorview.NewKeyMap(i18n.T("back")))
+			return m, nil
+		}
+		m.view = viewList
+		return m, pickCmd(msg.location, true)
 	case errorMsg:
 		m.view = viewError
+		m.locateFailed = false
+		m.failure = errorview.New(i18n.T("Failed to load recent locations"), msg.err, errorview.NewKeyMap(i18n.T("new search")))
 		return m, nil
 	}
 
 	// Forward messages to sub-components
 	var cmd tea.Cmd
+	if m.view == viewRename {
+		m.alias, cmd = m.alias.Update(msg)
+		return m, cmd
+	}
+	if m.view == viewLocating {
+		m.ellipsis, cmd = m.ellipsis.Update(msg)
+		return m, cmd
+	}
 	m.list, cmd = m.list.Update(msg)
 
 	return m, cmd
 }
 
+func (m Model) updateRename(msg tea.KeyMsg) (Model, tea.Cmd) {
+	if key.Matches(msg, m.renameKeys.save) {
+		m.view = viewList
+		m.alias.Blur()
+		selected, ok := m.list.SelectedItem().(recentLocationItem)
+		if !ok {
+			return m, nil
+		}
+		alias := m.alias.Value()
+		return m, changeCmd(m.list.Index(), func() error {
+			return store.RenameRecentLocation(selected.ID, alias)
+		})
+	}
+	if key.Matches(msg, m.renameKeys.cancel) {
+		m.view = viewList
+		m.alias.Blur()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.alias, cmd = m.alias.Update(msg)
+	return m, cmd
+}
+
 func (m Model) View() string {
 	switch m.view {
 	case viewList:
-		return "\nRecent locations:\n\n" + m.list.View() + "\n" + m.help.View(m.keys)
+		return "\n" + i18n.T("Recent locations:") + "\n\n" + m.list.View() + "\n" + m.help.View(m.keys)
+	case viewRename:
+		return "\n" + i18n.T("Alias for %s:", selectedName(m.list)) + "\n" + m.alias.View() + "\n\n" + m.help.View(m.renameKeys)
+	case viewConfirmClear:
+		return "\n" + i18n.T("Clear all recent locations?") + "\n\n" + m.list.View() + "\n" + m.help.View(m.confirmKeys)
+	case viewLocating:
+		return "\n" + i18n.T("Finding current position%s", m.ellipsis.View()) + "\n"
 	case viewError:
-		return "\nError occurred"
+		return m.failure.View()
 	default:
 		return "Unknown state (recent)"
 	}
 }
 
-func New() Model {
-	listDelegate := list.NewDefaultDelegate()
-	listDelegate.ShowDescription = false
-	list := list.New([]list.Item{}, listDelegate, 30, 14)
-	list.SetShowStatusBar(false)
-	list.SetFilteringEnabled(false)
-	list.SetShowHelp(false)
-	list.SetShowTitle(false)
+// Position of the first recent location, after the current location entry if there is one.
+func (m Model) first() int {
+	if len(m.sources) > 0 {
+		return 1
+	}
+	return 0
+}
+
+func selectedName(l list.Model) string {
+	selected, ok := l.SelectedItem().(recentLocationItem)
+	if !ok {
+		return ""
+	}
+	return selected.Label()
+}
+
+// Screen of recent locations, each with its last known conditions.
+// With autoPick, a single recent location is opened without asking, which is what startup wants.
+func New(cfg config.Config, autoPick bool) Model {
+	list := picker.New(40, 16)
+	delegate := theme.ListDelegate()
+	delegate.ShowDescription = true
+	list.SetDelegate(delegate)
+
+	alias := textinput.New()
+	alias.CharLimit = 64
+	alias.Width = 30
+	alias.Cursor.Style = theme.Current().Accent
+
+	ellipsis := spinner.New()
+	ellipsis.Spinner = spinner.Ellipsis
+	ellipsis.Style = theme.Current().Accent
+
+	// Checked at startup, a broken config only hides the entry
+	sources, _ := location.SourcesFromConfig(cfg)
 
 	return Model{
-		view: viewList,
-		list: list,
-		keys: newKeyMap(),
-		help: help.New(),
+		view:        viewList,
+		cfg:         cfg,
+		autoPick:    autoPick,
+		reselect:    -1,
+		list:        list,
+		keys:        newKeyMap(),
+		alias:       alias,
+		renameKeys:  newRenameKeyMap(),
+		confirmKeys: newConfirmKeyMap(),
+		sources:     sources,
+		ellipsis:    ellipsis,
+		help:        theme.Help(),
+		previews:    map[int]preview{},
+		workers:     make(chan struct{}, PREVIEW_WORKERS),
 	}
 }
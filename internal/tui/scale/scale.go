@@ -0,0 +1,182 @@
+package scale
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/esferadigital/clima/internal/derived"
+	"github.com/esferadigital/clima/internal/tui/theme"
+)
+
+// Point of a scale. Values from this one up to the next stop take its color.
+type Stop struct {
+	Value float64
+	// Truecolor hex code, e.g. "#ff0000". Empty leaves the value unstyled.
+	Color string
+	// Fallback for 16-color terminals, as an ANSI number.
+	ANSI string
+}
+
+// Maps values to colors. Gradients blend the colors between stops,
+// otherwise each stop colors a band of values.
+type Scale struct {
+	Stops    []Stop
+	Gradient bool
+	// Converts a value in the unit of the API response to the unit of the stops.
+	Normalize func(value float64, unit string) float64
+}
+
+// Color of a value in the given unit, or nil if it stays unstyled.
+// Terminals without truecolor get the nearest 256 or 16 color.
+func (s Scale) Color(value float64, unit string) lipgloss.TerminalColor {
+	if len(s.Stops) == 0 {
+		return nil
+	}
+	if s.Normalize != nil {
+		value = s.Normalize(value, unit)
+	}
+
+	i := 0
+	for i < len(s.Stops)-1 && value >= s.Stops[i+1].Value {
+		i++
+	}
+	stop := s.Stops[i]
+	if stop.Color == "" {
+		return nil
+	}
+
+	hex := stop.Color
+	if s.Gradient && i < len(s.Stops)-1 && value > stop.Value {
+		next := s.Stops[i+1]
+		t := (value - stop.Value) / (next.Value - stop.Value)
+		hex = blend(stop.Color, next.Color, t)
+		if t >= 0.5 {
+			stop = next
+		}
+	}
+
+	r, g, b := parseHex(hex)
+	return lipgloss.CompleteColor{
+		TrueColor: hex,
+		ANSI256:   strconv.Itoa(ansi256(r, g, b)),
+		ANSI:      stop.ANSI,
+	}
+}
+
+// Render text in the color of a value. Plain in the no-color theme.
+func (s Scale) Render(value float64, unit string, text string) string {
+	if theme.Current().Name == theme.NameNoColor {
+		return text
+	}
+	color := s.Color(value, unit)
+	if color == nil {
+		return text
+	}
+	return lipgloss.NewStyle().Foreground(color).Render(text)
+}
+
+// Temperature in °C, from deep cold blue to extreme heat red.
+var Temperature = Scale{
+	Stops: []Stop{
+		{Value: -20, Color: "#8a6cff", ANSI: "5"},
+		{Value: -5, Color: "#3d7eff", ANSI: "4"},
+		{Value: 5, Color: "#36c5f0", ANSI: "6"},
+		{Value: 15, Color: "#5fd068", ANSI: "2"},
+		{Value: 25, Color: "#f5c542", ANSI: "3"},
+		{Value: 32, Color: "#ff8a2a", ANSI: "11"},
+		{Value: 40, Color: "#ff3b3b", ANSI: "9"},
+	},
+	Gradient:  true,
+	Normalize: func(value float64, unit string) float64 { return derived.ToCelsius(value, unit) },
+}
+
+// UV index by WHO exposure category: low, moderate, high, very high and extreme.
+var UV = Scale{
+	Stops: []Stop{
+		{Value: 0, Color: "#5fd068", ANSI: "2"},
+		{Value: 3, Color: "#f5c542", ANSI: "3"},
+		{Value: 6, Color: "#ff8a2a", ANSI: "11"},
+		{Value: 8, Color: "#ff3b3b", ANSI: "9"},
+		{Value: 11, Color: "#c061ff", ANSI: "13"},
+	},
+}
+
+// Precipitation rate in mm per hour, from light to violent rain. Dry stays unstyled.
+var PrecipitationRate = Scale{
+	Stops: []Stop{
+		{Value: 0},
+		{Value: 0.1, Color: "#8fb8de", ANSI: "6"},
+		{Value: 2.5, Color: "#3d7eff", ANSI: "4"},
+		{Value: 10, Color: "#c061ff", ANSI: "13"},
+		{Value: 50, Color: "#ff3b3b", ANSI: "9"},
+	},
+	Normalize: toMillimeters,
+}
+
+// Daily precipitation in mm, from a wet day to heavy and extreme rainfall.
+var PrecipitationDaily = Scale{
+	Stops: []Stop{
+		{Value: 0},
+		{Value: 1, Color: "#8fb8de", ANSI: "6"},
+		{Value: 10, Color: "#3d7eff", ANSI: "4"},
+		{Value: 30, Color: "#c061ff", ANSI: "13"},
+		{Value: 70, Color: "#ff3b3b", ANSI: "9"},
+	},
+	Normalize: toMillimeters,
+}
+
+// Wind gusts in km/h by danger, following the Beaufort scale:
+// gale force is a warning, storm force a danger and hurricane force extreme.
+var WindGusts = Scale{
+	Stops: []Stop{
+		{Value: 0},
+		{Value: 50, Color: "#f5c542", ANSI: "3"},
+		{Value: 75, Color: "#ff8a2a", ANSI: "11"},
+		{Value: 89, Color: "#ff3b3b", ANSI: "9"},
+		{Value: 118, Color: "#c061ff", ANSI: "13"},
+	},
+	Normalize: func(value float64, unit string) float64 { return derived.ToKMH(value, unit) },
+}
+
+func toMillimeters(value float64, unit string) float64 {
+	if unit == "inch" {
+		return value * 25.4
+	}
+	return value
+}
+
+func parseHex(hex string) (r, g, b int) {
+	fmt.Sscanf(hex, "#%02x%02x%02x", &r, &g, &b)
+	return r, g, b
+}
+
+// Linear blend of two hex colors, t from 0 to 1.
+func blend(from string, to string, t float64) string {
+	r1, g1, b1 := parseHex(from)
+	r2, g2, b2 := parseHex(to)
+	mix := func(a, b int) int {
+		return int(math.Round(float64(a) + (float64(b)-float64(a))*t))
+	}
+	return fmt.Sprintf("#%02x%02x%02x", mix(r1, r2), mix(g1, g2), mix(b1, b2))
+}
+
+// Channel levels of the 6x6x6 color cube of 256-color terminals.
+var cubeLevels = []int{0, 95, 135, 175, 215, 255}
+
+// Nearest color of the 256-color cube.
+func ansi256(r, g, b int) int {
+	return 16 + 36*nearestLevel(r) + 6*nearestLevel(g) + nearestLevel(b)
+}
+
+func nearestLevel(v int) int {
+	best := 0
+	for i, level := range cubeLevels {
+		if math.Abs(float64(v-level)) < math.Abs(float64(v-cubeLevels[best])) {
+			best = i
+		}
+	}
+	return best
+}
@@ -1,25 +1,24 @@
 package search
 
 import (
-	"fmt"
-
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
 
+	"github.com/esferadigital/clima/internal/i18n"
 	"github.com/esferadigital/clima/internal/openmeteo"
+	"github.com/esferadigital/clima/internal/tui/errorview"
+	"github.com/esferadigital/clima/internal/tui/keybind"
+	"github.com/esferadigital/clima/internal/tui/nav"
+	"github.com/esferadigital/clima/internal/tui/picker"
+	"github.com/esferadigital/clima/internal/tui/theme"
 )
 
 const DEFAULT_SEARCH_COUNT = 10
 
-// ---- styles ----
-
-var accent = lipgloss.NewStyle().Foreground(lipgloss.Color("13"))
-
 // ---- keymap ----
 
 type inputKeyMap struct {
@@ -39,14 +38,8 @@ func (k inputKeyMap) FullHelp() [][]key.Binding {
 
 func newInputKeyMap() inputKeyMap {
 	return inputKeyMap{
-		submit: key.NewBinding(
-			key.WithKeys("enter"),
-			key.WithHelp("enter", "search"),
-		),
-		exitSearch: key.NewBinding(
-			key.WithKeys("esc"),
-			key.WithHelp("esc", "exit search"),
-		),
+		submit:     keybind.Binding(keybind.Search, i18n.T("search")),
+		exitSearch: keybind.Binding(keybind.ExitSearch, i18n.T("exit search")),
 	}
 }
 
@@ -55,11 +48,13 @@ type listKeyMap struct {
 	down      key.Binding
 	pick      key.Binding
 	newSearch key.Binding
+	filter    key.Binding
+	back      key.Binding
 	quit      key.Binding
 }
 
 func (k listKeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.up, k.down, k.pick, k.newSearch, k.quit}
+	return []key.Binding{k.up, k.down, k.pick, k.newSearch, k.filter, k.back, k.quit}
 }
 
 func (k listKeyMap) FullHelp() [][]key.Binding {
@@ -68,32 +63,21 @@ func (k listKeyMap) FullHelp() [][]key.Binding {
 		{k.down},
 		{k.pick},
 		{k.newSearch},
+		{k.filter},
+		{k.back},
 		{k.quit},
 	}
 }
 
 func newListKeyMap() listKeyMap {
 	return listKeyMap{
-		up: key.NewBinding(
-			key.WithKeys("↑"),
-			key.WithHelp("↑", "up"),
-		),
-		down: key.NewBinding(
-			key.WithKeys("↓"),
-			key.WithHelp("↓", "down"),
-		),
-		pick: key.NewBinding(
-			key.WithKeys("enter"),
-			key.WithHelp("enter", "pick"),
-		),
-		newSearch: key.NewBinding(
-			key.WithKeys("n"),
-			key.WithHelp("n", "new search"),
-		),
-		quit: key.NewBinding(
-			key.WithKeys("q"),
-			key.WithHelp("q", "quit"),
-		),
+		up:        keybind.Binding(keybind.Up, i18n.T("up")),
+		down:      keybind.Binding(keybind.Down, i18n.T("down")),
+		pick:      keybind.Binding(keybind.Pick, i18n.T("pick")),
+		newSearch: keybind.Binding(keybind.NewSearch, i18n.T("new search")),
+		filter:    keybind.Binding(keybind.Filter, i18n.T("filter")),
+		back:      keybind.Binding(keybind.Back, i18n.T("edit search")),
+		quit:      keybind.Binding(keybind.Quit, i18n.T("quit")),
 	}
 }
 
@@ -111,8 +95,6 @@ type SearchCompleteMsg struct {
 	Location openmeteo.GeocodingResult
 }
 
-type RecentMsg struct{}
-
 // ---- helpers ----
 
 // Implements list.Item interface and wraps openmeteo.GeocodingResult
@@ -120,16 +102,23 @@ type searchListItem struct {
 	openmeteo.GeocodingResult
 }
 
+// Starts with the title, which is where matches are highlighted.
 func (i searchListItem) FilterValue() string {
-	return i.Name
+	return i.Title()
 }
 
+// Region included, as many places share a name within a country.
 func (i searchListItem) Title() string {
-	return fmt.Sprintf("%s, %s", i.Name, i.Country)
+	if i.Admin1 == "" || i.Admin1 == i.Name {
+		return i.Label()
+	}
+	region := i.GeocodingResult
+	region.Name += ", " + i.Admin1
+	return region.Label()
 }
 
 func (i searchListItem) Description() string {
-	return fmt.Sprintf("Lat: %.4f, Lon: %.4f", i.Latitude, i.Longitude)
+	return i18n.T("Lat: %.4f, Lon: %.4f", i.Latitude, i.Longitude)
 }
 
 // ---- cmd ----
@@ -137,8 +126,9 @@ func (i searchListItem) Description() string {
 func searchLocationsCmd(name string) tea.Cmd {
 	return func() tea.Msg {
 		params := openmeteo.GeocodingParams{
-			Name:  name,
-			Count: DEFAULT_SEARCH_COUNT,
+			Name:     name,
+			Count:    DEFAULT_SEARCH_COUNT,
+			Language: string(i18n.Current()),
 		}
 		res, err := openmeteo.SearchLocation(params)
 		if err != nil {
@@ -160,12 +150,6 @@ func pickCmd(location openmeteo.GeocodingResult) tea.Cmd {
 	}
 }
 
-func requestRecentCmd() tea.Cmd {
-	return func() tea.Msg {
-		return RecentMsg{}
-	}
-}
-
 // ---- model ----
 
 type view int
@@ -184,6 +168,7 @@ type Model struct {
 	ellipsis  spinner.Model
 	list      list.Model
 	listKeys  listKeyMap
+	failure   errorview.Model
 	help      help.Model
 }
 
@@ -194,17 +179,36 @@ func (m Model) Init() tea.Cmd {
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.view == viewError {
+			if key.Matches(msg, m.failure.Keys.Retry) {
+				m.view = viewLoading
+				return m, tea.Batch(searchLocationsCmd(m.input.Value()), m.ellipsis.Tick)
+			}
+			// Keep the query so it can be corrected
+			if key.Matches(msg, m.failure.Keys.Back) {
+				m.view = viewInput
+				return m, textinput.Blink
+			}
+			if key.Matches(msg, m.failure.Keys.Quit) {
+				return m, tea.Quit
+			}
+		}
 		if m.view == viewInput {
 			if key.Matches(msg, m.inputKeys.submit) {
 				m.view = viewLoading
 				return m, tea.Batch(searchLocationsCmd(m.input.Value()), m.ellipsis.Tick)
 			}
 			if key.Matches(msg, m.inputKeys.exitSearch) {
-				return m, requestRecentCmd()
+				return m, nav.Back()
 			}
 		}
 		if m.view == viewPick {
-			if key.Matches(msg, m.inputKeys.submit) {
+			if picker.HandlesKey(m.list, msg) {
+				var cmd tea.Cmd
+				m.list, cmd = m.list.Update(msg)
+				return m, cmd
+			}
+			if key.Matches(msg, m.listKeys.pick) {
 				picked, ok := m.list.SelectedItem().(searchListItem)
 				if ok {
 					return m, pickCmd(picked.GeocodingResult)
@@ -215,21 +219,33 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				m.view = viewInput
 				return m, nil
 			}
+			// Back to the query, as it was typed
+			if key.Matches(msg, m.listKeys.back) {
+				m.view = viewInput
+				return m, textinput.Blink
+			}
+			if key.Matches(msg, m.listKeys.quit) {
+				return m, tea.Quit
+			}
 		}
 
 	case dataMsg:
 		if len(msg.locations) == 1 {
+			// Coming back from the forecast lands on the query
+			m.view = viewInput
 			return m, pickCmd(msg.locations[0])
 		}
 		items := make([]list.Item, len(msg.locations))
 		for i, loc := range msg.locations {
 			items[i] = searchListItem{loc}
 		}
+		m.list.ResetFilter()
 		m.list.SetItems(items)
 		m.view = viewPick
 		return m, nil
 	case errorMsg:
 		m.view = viewError
+		m.failure = errorview.New(i18n.T("Failed to find locations"), msg.err, errorview.NewKeyMap(i18n.T("new search")))
 		return m, nil
 	}
 
@@ -251,13 +267,13 @@ func (m Model) View() string {
 	view := "\n"
 	switch m.view {
 	case viewInput:
-		return view + "Location search:\n" + m.input.View() + "\n\n" + m.help.View(m.inputKeys)
+		return view + i18n.T("Location search:") + "\n" + m.input.View() + "\n\n" + m.help.View(m.inputKeys)
 	case viewLoading:
-		return view + fmt.Sprintf("Finding location%s\n", m.ellipsis.View())
+		return view + i18n.T("Finding location%s\n", m.ellipsis.View())
 	case viewPick:
-		return view + "Pick a location:\n\n" + m.list.View() + "\n" + m.help.View(m.listKeys)
+		return view + i18n.T("Pick a location:") + "\n\n" + m.list.View() + "\n" + m.help.View(m.listKeys)
 	case viewError:
-		return view + "Error ... try again or quit\n"
+		return m.failure.View()
 	default:
 		return ""
 	}
@@ -269,19 +285,13 @@ func New() Model {
 	input.Focus()
 	input.CharLimit = 256
 	input.Width = 20
-	input.Cursor.Style = accent
+	input.Cursor.Style = theme.Current().Accent
 
 	ellipsis := spinner.New()
 	ellipsis.Spinner = spinner.Ellipsis
-	ellipsis.Style = accent
+	ellipsis.Style = theme.Current().Accent
 
-	listDelegate := list.NewDefaultDelegate()
-	listDelegate.ShowDescription = false
-	list := list.New([]list.Item{}, listDelegate, 30, 14)
-	list.SetShowStatusBar(false)
-	list.SetFilteringEnabled(false)
-	list.SetShowHelp(false)
-	list.SetShowTitle(false)
+	list := picker.New(30, 14)
 
 	return Model{
 		view:      viewInput,
@@ -290,6 +300,6 @@ func New() Model {
 		ellipsis:  ellipsis,
 		list:      list,
 		listKeys:  newListKeyMap(),
-		help:      help.New(),
+		help:      theme.Help(),
 	}
 }
@@ -0,0 +1,204 @@
+package theme
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Name of a built-in theme, as written in the config.
+type Name string
+
+const (
+	// Dark or light, depending on the terminal background.
+	NameAuto         Name = "auto"
+	NameDark         Name = "dark"
+	NameLight        Name = "light"
+	NameHighContrast Name = "high-contrast"
+	NameNoColor      Name = "no-color"
+)
+
+// Colors by role. Values are ANSI numbers ("13") or hex codes ("#ff87d7").
+// An empty color leaves the terminal default.
+type Palette struct {
+	Accent string
+	Subtle string
+	Label  string
+	// Severity levels, from good to hazardous. Used for air quality.
+	Levels [6]string
+}
+
+// Roles that can be set from the config, mapped to their place in a palette.
+func (p *Palette) roles() map[string]*string {
+	return map[string]*string{
+		"accent":    &p.Accent,
+		"subtle":    &p.Subtle,
+		"label":     &p.Label,
+		"good":      &p.Levels[0],
+		"fair":      &p.Levels[1],
+		"moderate":  &p.Levels[2],
+		"poor":      &p.Levels[3],
+		"very_poor": &p.Levels[4],
+		"hazardous": &p.Levels[5],
+	}
+}
+
+var palettes = map[Name]Palette{
+	NameDark: {
+		Accent: "13",
+		Subtle: "8",
+		Label:  "8",
+		Levels: [6]string{"10", "2", "11", "9", "1", "5"},
+	},
+	NameLight: {
+		Accent: "5",
+		Subtle: "244",
+		Label:  "242",
+		Levels: [6]string{"2", "28", "130", "1", "124", "90"},
+	},
+	NameHighContrast: {
+		Accent: "14",
+		Subtle: "15",
+		Label:  "15",
+		Levels: [6]string{"10", "10", "11", "9", "9", "13"},
+	},
+	NameNoColor: {},
+}
+
+// Styles shared by every screen.
+type Theme struct {
+	Name   Name
+	Accent lipgloss.Style
+	Subtle lipgloss.Style
+	Label  lipgloss.Style
+	// Indexed by openmeteo.AQICategory.Level
+	Levels [6]lipgloss.Style
+}
+
+func newTheme(name Name, p Palette) Theme {
+	t := Theme{
+		Name:   name,
+		Accent: foreground(p.Accent),
+		Subtle: foreground(p.Subtle),
+		Label:  foreground(p.Label),
+	}
+	for i, color := range p.Levels {
+		t.Levels[i] = foreground(color)
+	}
+	if name == NameHighContrast {
+		t.Accent = t.Accent.Bold(true)
+		t.Label = t.Label.Bold(true)
+	}
+	return t
+}
+
+func foreground(color string) lipgloss.Style {
+	if color == "" {
+		return lipgloss.NewStyle()
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(color))
+}
+
+// Build a theme by name, with colors from the config replacing its own.
+// NO_COLOR wins over both, see https://no-color.org.
+// Auto detection queries the terminal, so call it before the program starts.
+func Load(name string, colors map[string]string) (Theme, error) {
+	n := Name(name)
+	switch n {
+	case "", NameAuto:
+		n = NameDark
+		if !lipgloss.HasDarkBackground() {
+			n = NameLight
+		}
+	case NameDark, NameLight, NameHighContrast, NameNoColor:
+	default:
+		return Theme{}, fmt.Errorf("unknown theme %q: use auto, dark, light, high-contrast or no-color", name)
+	}
+
+	palette := palettes[n]
+	roles := palette.roles()
+	for role, color := range colors {
+		target, ok := roles[role]
+		if !ok {
+			return Theme{}, fmt.Errorf("unknown theme color %q: use %s", role, strings.Join(roleNames(), ", "))
+		}
+		*target = color
+	}
+
+	if os.Getenv("NO_COLOR") != "" {
+		return newTheme(NameNoColor, palettes[NameNoColor]), nil
+	}
+	return newTheme(n, palette), nil
+}
+
+func roleNames() []string {
+	var names []string
+	for role := range (&Palette{}).roles() {
+		names = append(names, role)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var current = newTheme(NameDark, palettes[NameDark])
+
+// Set the theme used by every screen from now on.
+// Called once at startup, before any model is built.
+func Set(t Theme) {
+	current = t
+}
+
+func Current() Theme {
+	return current
+}
+
+// List delegate in the colors of the current theme.
+func ListDelegate() list.DefaultDelegate {
+	delegate := list.NewDefaultDelegate()
+	delegate.ShowDescription = false
+
+	styles := list.NewDefaultItemStyles()
+	if current.Name == NameNoColor {
+		// Keep the selection visible without color
+		styles.NormalTitle = lipgloss.NewStyle().Padding(0, 0, 0, 2)
+		styles.SelectedTitle = lipgloss.NewStyle().
+			Border(lipgloss.NormalBorder(), false, false, false, true).
+			Padding(0, 0, 0, 1).
+			Bold(true)
+		styles.DimmedTitle = styles.NormalTitle
+		styles.NormalDesc = styles.NormalTitle
+		styles.SelectedDesc = styles.SelectedTitle.Bold(false)
+		styles.DimmedDesc = styles.NormalDesc
+	} else {
+		selected := current.Accent.GetForeground()
+		styles.SelectedTitle = styles.SelectedTitle.Foreground(selected).BorderForeground(selected)
+		styles.SelectedDesc = styles.SelectedDesc.Foreground(selected).BorderForeground(selected)
+		styles.DimmedTitle = styles.DimmedTitle.Foreground(current.Subtle.GetForeground())
+	}
+	styles.FilterMatch = styles.FilterMatch.Inherit(current.Accent)
+	delegate.Styles = styles
+
+	return delegate
+}
+
+// Help in the colors of the current theme.
+func Help() help.Model {
+	h := help.New()
+	if current.Name == NameNoColor {
+		h.Styles = help.Styles{}
+		return h
+	}
+	h.Styles.ShortKey = current.Label
+	h.Styles.FullKey = current.Label
+	h.Styles.ShortDesc = current.Subtle
+	h.Styles.FullDesc = current.Subtle
+	h.Styles.ShortSeparator = current.Subtle
+	h.Styles.FullSeparator = current.Subtle
+	h.Styles.Ellipsis = current.Subtle
+	return h
+}
@@ -0,0 +1,117 @@
+package weather
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/esferadigital/clima/internal/i18n"
+	"github.com/esferadigital/clima/internal/openmeteo"
+	"github.com/esferadigital/clima/internal/tui/theme"
+)
+
+// ---- msg ----
+
+type airQualityMsg struct {
+	data openmeteo.AirQualityResponse
+	err  error
+}
+
+// ---- cmd ----
+
+func getAirQualityCmd(lat float64, long float64) tea.Cmd {
+	return func() tea.Msg {
+		params := openmeteo.AirQualityParams{
+			Latitude:  lat,
+			Longitude: long,
+			Current: []openmeteo.AirQualityVariables{
+				openmeteo.EuropeanAQI,
+				openmeteo.USAQI,
+				openmeteo.PM2_5,
+				openmeteo.PM10,
+				openmeteo.Ozone,
+				openmeteo.NitrogenDioxide,
+				openmeteo.AlderPollen,
+				openmeteo.BirchPollen,
+				openmeteo.GrassPollen,
+				openmeteo.MugwortPollen,
+				openmeteo.OlivePollen,
+				openmeteo.RagweedPollen,
+			},
+		}
+		res, err := openmeteo.GetAirQuality(params)
+		return airQualityMsg{data: res, err: err}
+	}
+}
+
+// ---- model ----
+
+// Air quality tab state. It loads independently of the forecast
+// so a failure here does not hide the rest of the weather.
+type airQuality struct {
+	data   openmeteo.AirQualityResponse
+	err    error
+	loaded bool
+}
+
+func (a airQuality) view() string {
+	if !a.loaded {
+		return i18n.T("\nLoading air quality...")
+	}
+	if a.err != nil {
+		return i18n.T("\nFailed to get air quality: %s", a.err.Error())
+	}
+
+	s := ""
+	if aqi, ok := a.data.Current[string(openmeteo.EuropeanAQI)].(float64); ok {
+		category := openmeteo.EuropeanAQICategory(aqi)
+		s += label().Render("\n"+i18n.T("European AQI")) + theme.Current().Levels[category.Level].Render(i18n.Sprintf("%.0f %s", aqi, i18n.T(category.Name)))
+	}
+	if aqi, ok := a.data.Current[string(openmeteo.USAQI)].(float64); ok {
+		category := openmeteo.USAQICategory(aqi)
+		s += label().Render("\n"+i18n.T("US AQI")) + theme.Current().Levels[category.Level].Render(i18n.Sprintf("%.0f %s", aqi, i18n.T(category.Name)))
+	}
+
+	pollutants := []struct {
+		name     string
+		variable openmeteo.AirQualityVariables
+	}{
+		{"\n\nPM2.5", openmeteo.PM2_5},
+		{"\nPM10", openmeteo.PM10},
+		{"\nOzone", openmeteo.Ozone},
+		{"\nNitrogen dioxide", openmeteo.NitrogenDioxide},
+	}
+	for _, p := range pollutants {
+		s += label().Render(i18n.T(p.name)) + a.value(p.variable)
+	}
+
+	pollens := []struct {
+		name     string
+		variable openmeteo.AirQualityVariables
+	}{
+		{"\nAlder pollen", openmeteo.AlderPollen},
+		{"\nBirch pollen", openmeteo.BirchPollen},
+		{"\nGrass pollen", openmeteo.GrassPollen},
+		{"\nMugwort pollen", openmeteo.MugwortPollen},
+		{"\nOlive pollen", openmeteo.OlivePollen},
+		{"\nRagweed pollen", openmeteo.RagweedPollen},
+	}
+	pollenView := ""
+	for _, p := range pollens {
+		// Pollen is only forecast in Europe, skip it elsewhere
+		if _, ok := a.data.Current[string(p.variable)].(float64); ok {
+			pollenView += label().Render(i18n.T(p.name)) + a.value(p.variable)
+		}
+	}
+	if pollenView != "" {
+		s += "\n" + pollenView
+	}
+
+	return s
+}
+
+func (a airQuality) value(variable openmeteo.AirQualityVariables) string {
+	v, ok := a.data.Current[string(variable)].(float64)
+	if !ok {
+		return "-"
+	}
+	return i18n.Sprintf("%.1f %s", v, a.data.CurrentUnits[string(variable)])
+}
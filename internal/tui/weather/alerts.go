@@ -0,0 +1,23 @@
+package weather
+
+import (
+	"github.com/esferadigital/clima/internal/i18n"
+	"github.com/esferadigital/clima/internal/tui/theme"
+)
+
+// ---- view ----
+
+// Banner with the alert rules that hold, one line each, e.g.
+// "! Gusty: wind_gusts_10m 72.0 km/h at 15:00". Empty without alerts.
+func (m Model) alertsView() string {
+	if len(m.alerts) == 0 {
+		return ""
+	}
+	// Same level as poor air quality
+	style := theme.Current().Levels[3].Bold(true)
+	s := "\n"
+	for _, a := range m.alerts {
+		s += "\n" + style.Render(i18n.T("! %s:", a.Rule.Name)) + " " + a.Details()
+	}
+	return s
+}
@@ -0,0 +1,117 @@
+package weather
+
+import (
+	"math"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/esferadigital/clima/internal/astro"
+	"github.com/esferadigital/clima/internal/i18n"
+	"github.com/esferadigital/clima/internal/openmeteo"
+	"github.com/esferadigital/clima/internal/tui/theme"
+)
+
+// Computed and API sun times further apart than this are flagged.
+const SUN_TOLERANCE = 2 * time.Minute
+
+// ---- msg ----
+
+// Keeps countdowns current. The id drops ticks from a replaced clock.
+type clockMsg struct {
+	id  int
+	now time.Time
+}
+
+// ---- cmd ----
+
+func tickClockCmd(id int) tea.Cmd {
+	return tea.Tick(time.Minute, func(t time.Time) tea.Msg {
+		return clockMsg{id: id, now: t}
+	})
+}
+
+// ---- view ----
+
+type sunEvent struct {
+	name string
+	at   time.Time
+	// Daily variable with the same event, to cross-check the computation
+	api openmeteo.DailyWeatherVariables
+}
+
+func sunEvents(events astro.SunEvents) []sunEvent {
+	return []sunEvent{
+		{name: "Nautical dawn", at: events.NauticalDawn},
+		{name: "Civil dawn", at: events.CivilDawn},
+		{name: "Sunrise", at: events.Sunrise, api: openmeteo.Sunrise},
+		{name: "Solar noon", at: events.SolarNoon},
+		{name: "Sunset", at: events.Sunset, api: openmeteo.Sunset},
+		{name: "Civil dusk", at: events.CivilDusk},
+		{name: "Nautical dusk", at: events.NauticalDusk},
+	}
+}
+
+func (m Model) astronomyView() string {
+	lat, long := m.location.Latitude, m.location.Longitude
+	loc := m.forecast.Location()
+	now := m.now.In(loc)
+	today := astro.Sun(now, lat, long)
+
+	s := ""
+	elevation := astro.SolarElevation(now, lat, long)
+	period := "Night"
+	if isDay, ok := m.forecast.Current[string(openmeteo.IsDay)].(float64); ok && isDay == 1 {
+		period = "Day"
+	}
+	s += label().Render("\n"+i18n.T("Now")) + i18n.T("%s, sun at %.1f°", i18n.T(period), elevation)
+
+	s += "\n"
+	for _, event := range sunEvents(today) {
+		s += label().Render("\n"+i18n.T(event.name)) + m.sunTimeView(event)
+	}
+	s += label().Render("\n"+i18n.T("Day length")) + formatDuration(today.DayLength)
+
+	tomorrow := astro.Sun(now.AddDate(0, 0, 1), lat, long)
+	for _, event := range append(sunEvents(today), sunEvents(tomorrow)...) {
+		if !event.at.IsZero() && event.at.After(now) {
+			s += label().Render("\n\n"+i18n.T("Next")) + theme.Current().Accent.Render(i18n.T("%s in %s", i18n.T(event.name), formatDuration(event.at.Sub(now))))
+			break
+		}
+	}
+
+	moon := astro.Moon(now)
+	s += label().Render("\n\n"+i18n.T("Moon")) + i18n.T("%s, %.0f%% lit", i18n.T(moon.Name), moon.Illumination*100)
+	s += theme.Current().Subtle.Render(i18n.T(" (%.1f days old)", moon.Age))
+
+	return s
+}
+
+// Time of an event, with the API value when it disagrees with the computation.
+func (m Model) sunTimeView(event sunEvent) string {
+	if event.at.IsZero() {
+		return "-"
+	}
+	s := event.at.Format("15:04")
+	if event.api == "" {
+		return s
+	}
+
+	value, ok := openmeteo.SeriesString(m.forecast.Daily, string(event.api), 0)
+	if !ok {
+		return s
+	}
+	fromAPI, err := time.ParseInLocation(openmeteo.TIME_LAYOUT, value, m.forecast.Location())
+	if err != nil {
+		return s
+	}
+	if math.Abs(float64(fromAPI.Sub(event.at))) > float64(SUN_TOLERANCE) {
+		s += theme.Current().Subtle.Render(i18n.Sprintf(" (API %s)", fromAPI.Format("15:04")))
+	}
+	return s
+}
+
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	return i18n.Sprintf("%dh %02dm", int(d.Hours()), int(d.Minutes())%60)
+}
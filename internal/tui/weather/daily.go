@@ -0,0 +1,146 @@
+package weather
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/esferadigital/clima/internal/climate"
+	"github.com/esferadigital/clima/internal/i18n"
+	"github.com/esferadigital/clima/internal/openmeteo"
+	"github.com/esferadigital/clima/internal/tui/scale"
+	"github.com/esferadigital/clima/internal/tui/theme"
+)
+
+// ---- styles ----
+
+// Columns of the daily table, narrow enough for rows to fit in 80 columns
+// without wrapping, which would break their colors.
+var (
+	dayCell = lipgloss.NewStyle().Width(8)
+	wide    = lipgloss.NewStyle().Width(CONDITIONS_WIDTH)
+	cell    = lipgloss.NewStyle().Width(10)
+	uvCell  = lipgloss.NewStyle().Width(6)
+)
+
+// Width of the conditions column. Longer descriptions are cut.
+const CONDITIONS_WIDTH = 22
+
+// ---- msg ----
+
+type normalsMsg struct {
+	normals climate.Normals
+	err     error
+}
+
+// ---- cmd ----
+
+func getNormalsCmd(lat float64, long float64, unit openmeteo.TemperatureUnit) tea.Cmd {
+	return func() tea.Msg {
+		normals, err := climate.Load(lat, long, unit)
+		return normalsMsg{normals: normals, err: err}
+	}
+}
+
+// ---- view ----
+
+// Normals for the day at position `i` of the daily forecast.
+func (m Model) normalFor(i int) (climate.Day, bool) {
+	if m.normals == nil {
+		return climate.Day{}, false
+	}
+	times := openmeteo.SeriesTimes(m.forecast.Daily)
+	if i >= len(times) {
+		return climate.Day{}, false
+	}
+	date, err := time.Parse(time.DateOnly, times[i])
+	if err != nil {
+		return climate.Day{}, false
+	}
+	return m.normals.For(date), true
+}
+
+func anomalyView(anomaly climate.Anomaly, record string) string {
+	if anomaly.Record {
+		return theme.Current().Accent.Render(i18n.Sprintf("  %s, %s", anomalyText(anomaly), i18n.T(record)))
+	}
+	return theme.Current().Subtle.Render("  " + anomalyText(anomaly))
+}
+
+// Forecast for the coming days, one row per day.
+func (m Model) dailyView() string {
+	daily := m.forecast.Daily
+	units := m.forecast.DailyUnits
+
+	s := theme.Current().Subtle.Render(dayCell.Render(i18n.T("Day")) + wide.Render(i18n.T("Conditions")) + cell.Render(i18n.T("Min")) + cell.Render(i18n.T("Max")) + cell.Render(i18n.T("Precip.")) + uvCell.Render(i18n.T("UV")) + i18n.T("Max vs normal"))
+	for i, t := range openmeteo.SeriesTimes(daily) {
+		day := t
+		if date, err := time.Parse(time.DateOnly, t); err == nil {
+			day = i18n.Day(date)
+		}
+
+		conditions := "-"
+		if code, ok := openmeteo.SeriesValue(daily, string(openmeteo.DailyWeatherCode), i); ok {
+			conditions = fit(i18n.T(openmeteo.MapWeatherCode(code)), CONDITIONS_WIDTH)
+		}
+
+		row := dayCell.Render(day) + wide.Render(conditions)
+		columns := []struct {
+			variable openmeteo.DailyWeatherVariables
+			scale    scale.Scale
+		}{
+			{openmeteo.Temperature2mMin, scale.Temperature},
+			{openmeteo.Temperature2mMax, scale.Temperature},
+			{openmeteo.PrecipitationSum, scale.PrecipitationDaily},
+		}
+		for _, c := range columns {
+			value, ok := openmeteo.SeriesValue(daily, string(c.variable), i)
+			if !ok {
+				row += cell.Render("-")
+				continue
+			}
+			unit, _ := units[string(c.variable)].(string)
+			row += cell.Render(c.scale.Render(value, unit, i18n.Sprintf("%.1f %s", value, unit)))
+		}
+		if uv, ok := openmeteo.SeriesValue(daily, string(openmeteo.UVIndexMax), i); ok {
+			row += uvCell.Render(scale.UV.Render(uv, "", i18n.Sprintf("%.1f", uv)))
+		} else {
+			row += uvCell.Render("-")
+		}
+
+		high, ok := openmeteo.SeriesValue(daily, string(openmeteo.Temperature2mMax), i)
+		if normal, hasNormal := m.normalFor(i); ok && hasNormal {
+			anomaly := normal.MaxAnomaly(high)
+			if anomaly.Record {
+				row += theme.Current().Accent.Render(i18n.T("%+.1f° record", anomaly.Delta))
+			} else {
+				row += theme.Current().Subtle.Render(i18n.Sprintf("%+.1f°", anomaly.Delta))
+			}
+		}
+
+		s += "\n" + row
+	}
+	return s
+}
+
+// Cut plain text to fit a column, keeping a space before the next one.
+func fit(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) < width {
+		return text
+	}
+	return string(runes[:width-2]) + "…"
+}
+
+// Localized description of an anomaly, e.g. "+3.2° above normal".
+func anomalyText(anomaly climate.Anomaly) string {
+	switch {
+	case anomaly.Delta >= 0.05:
+		return i18n.T("%+.1f° above normal", anomaly.Delta)
+	case anomaly.Delta <= -0.05:
+		return i18n.T("%+.1f° below normal", anomaly.Delta)
+	default:
+		return i18n.T("normal")
+	}
+}
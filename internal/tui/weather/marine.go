@@ -0,0 +1,88 @@
+package weather
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/esferadigital/clima/internal/derived"
+	"github.com/esferadigital/clima/internal/i18n"
+	"github.com/esferadigital/clima/internal/openmeteo"
+)
+
+// ---- msg ----
+
+type marineMsg struct {
+	data openmeteo.MarineResponse
+	err  error
+}
+
+// ---- cmd ----
+
+func getMarineCmd(lat float64, long float64) tea.Cmd {
+	return func() tea.Msg {
+		params := openmeteo.MarineParams{
+			Latitude:  lat,
+			Longitude: long,
+			Current: []openmeteo.MarineVariables{
+				openmeteo.WaveHeight,
+				openmeteo.WaveDirection,
+				openmeteo.WavePeriod,
+				openmeteo.WindWaveHeight,
+				openmeteo.SwellWaveHeight,
+				openmeteo.SwellWaveDirection,
+				openmeteo.SwellWavePeriod,
+				openmeteo.SeaSurfaceTemperature,
+				openmeteo.OceanCurrentVelocity,
+				openmeteo.OceanCurrentDirection,
+			},
+		}
+		res, err := openmeteo.GetMarine(params)
+		return marineMsg{data: res, err: err}
+	}
+}
+
+// ---- model ----
+
+// Marine tab state. The tab only exists for coastal locations,
+// so errors and empty responses just keep it hidden.
+type marine struct {
+	data openmeteo.MarineResponse
+}
+
+func (s marine) available() bool {
+	return s.data.HasData()
+}
+
+func (s marine) view() string {
+	out := ""
+	rows := []struct {
+		name     string
+		variable openmeteo.MarineVariables
+	}{
+		{"\nWaves", openmeteo.WaveHeight},
+		{"\nWave period", openmeteo.WavePeriod},
+		{"\nWave direction", openmeteo.WaveDirection},
+		{"\nWind waves", openmeteo.WindWaveHeight},
+		{"\n\nSwell", openmeteo.SwellWaveHeight},
+		{"\nSwell period", openmeteo.SwellWavePeriod},
+		{"\nSwell direction", openmeteo.SwellWaveDirection},
+		{"\n\nSea temperature", openmeteo.SeaSurfaceTemperature},
+		{"\nCurrent", openmeteo.OceanCurrentVelocity},
+		{"\nCurrent direction", openmeteo.OceanCurrentDirection},
+	}
+	for _, r := range rows {
+		out += label().Render(i18n.T(r.name)) + s.value(r.variable)
+	}
+	return out
+}
+
+func (s marine) value(variable openmeteo.MarineVariables) string {
+	v, ok := s.data.Current[string(variable)].(float64)
+	if !ok {
+		return "-"
+	}
+	switch variable {
+	case openmeteo.WaveDirection, openmeteo.SwellWaveDirection, openmeteo.OceanCurrentDirection:
+		return i18n.Sprintf("%s (%.0f%s)", i18n.T(derived.Compass(v)), v, s.data.CurrentUnits[string(variable)])
+	}
+	return i18n.Sprintf("%.1f %s", v, s.data.CurrentUnits[string(variable)])
+}
@@ -0,0 +1,92 @@
+package weather
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/esferadigital/clima/internal/i18n"
+	"github.com/esferadigital/clima/internal/openmeteo"
+	"github.com/esferadigital/clima/internal/tui/theme"
+)
+
+// ---- msg ----
+
+// Time to refresh in the background. The id drops ticks from a replaced timer.
+type refreshMsg struct {
+	id int
+}
+
+// ---- cmd ----
+
+func tickRefreshCmd(id int, interval time.Duration) tea.Cmd {
+	if interval <= 0 {
+		return nil
+	}
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return refreshMsg{id: id}
+	})
+}
+
+// ---- model ----
+
+// Auto-refresh interval from the config, zero when disabled.
+func (m Model) refreshInterval() time.Duration {
+	if m.cfg.RefreshMinutes <= 0 {
+		return 0
+	}
+	return time.Duration(m.cfg.RefreshMinutes) * time.Minute
+}
+
+// Whether the data is older than the refresh interval.
+func (m Model) refreshDue() bool {
+	interval := m.refreshInterval()
+	return interval > 0 && !m.fetchedAt.IsZero() && time.Since(m.fetchedAt) >= interval
+}
+
+// Fetch again while the current data stays on screen.
+func (m Model) startRefresh() (Model, tea.Cmd) {
+	if m.refreshing {
+		return m, nil
+	}
+	m.refreshing = true
+	m.refreshErr = ""
+	return m, tea.Batch(m.fetchCmd(), m.ellipsis.Tick)
+}
+
+// Restart the timer, dropping ticks from the previous one.
+func (m Model) restartRefreshTimer() (Model, tea.Cmd) {
+	m.refreshID = nextTickID()
+	return m, tickRefreshCmd(m.refreshID, m.refreshInterval())
+}
+
+// ---- view ----
+
+// Age of the data from the time of the current conditions, e.g. "updated 3 min ago".
+func (m Model) updatedView() string {
+	if m.refreshing {
+		return theme.Current().Subtle.Render(i18n.T("updating%s", m.ellipsis.View()))
+	}
+	if m.refreshErr != "" {
+		return theme.Current().Subtle.Render(i18n.T("refresh failed, showing older data"))
+	}
+
+	value, ok := m.forecast.Current["time"].(string)
+	if !ok {
+		return ""
+	}
+	updated, err := time.ParseInLocation(openmeteo.TIME_LAYOUT, value, m.forecast.Location())
+	if err != nil {
+		return ""
+	}
+
+	minutes := int(m.now.Sub(updated).Minutes())
+	switch {
+	case minutes < 1:
+		return theme.Current().Subtle.Render(i18n.T("updated just now"))
+	case minutes < 60:
+		return theme.Current().Subtle.Render(i18n.T("updated %d min ago", minutes))
+	default:
+		return theme.Current().Subtle.Render(i18n.T("updated %d h ago", minutes/60))
+	}
+}
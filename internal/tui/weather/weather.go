@@ -1,7 +1,8 @@
 package weather
 
 import (
-	"fmt"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
@@ -9,17 +10,29 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/esferadigital/clima/internal/alert"
+	"github.com/esferadigital/clima/internal/climate"
+	"github.com/esferadigital/clima/internal/config"
+	"github.com/esferadigital/clima/internal/derived"
+	"github.com/esferadigital/clima/internal/forecast"
+	"github.com/esferadigital/clima/internal/i18n"
 	"github.com/esferadigital/clima/internal/openmeteo"
+	"github.com/esferadigital/clima/internal/provider"
 	"github.com/esferadigital/clima/internal/store"
+	"github.com/esferadigital/clima/internal/tui/errorview"
+	"github.com/esferadigital/clima/internal/tui/icons"
+	"github.com/esferadigital/clima/internal/tui/keybind"
+	"github.com/esferadigital/clima/internal/tui/nav"
+	"github.com/esferadigital/clima/internal/tui/scale"
+	"github.com/esferadigital/clima/internal/tui/theme"
 )
 
 // ---- styles ----
 
-var (
-	accent = lipgloss.NewStyle().Foreground(lipgloss.Color("13"))
-	subtle = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
-	label  = lipgloss.NewStyle().Width(20).Foreground(lipgloss.Color("8"))
-)
+// Colors come from the theme, which is only known once the config is loaded.
+func label() lipgloss.Style {
+	return theme.Current().Label.Width(20)
+}
 
 // ---- keymap ----
 
@@ -27,38 +40,34 @@ type keyMap struct {
 	newSearch       key.Binding
 	recentLocations key.Binding
 	refresh         key.Binding
+	compare         key.Binding
+	history         key.Binding
+	nextTab         key.Binding
+	back            key.Binding
 	quit            key.Binding
 }
 
 func (k keyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.newSearch, k.recentLocations, k.refresh, k.quit}
+	return []key.Binding{k.nextTab, k.newSearch, k.recentLocations, k.refresh, k.compare, k.history, k.back, k.quit}
 }
 
 func (k keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.newSearch}, {k.recentLocations},
-		{k.refresh}, {k.quit},
+		{k.nextTab}, {k.newSearch}, {k.recentLocations},
+		{k.refresh}, {k.compare}, {k.history}, {k.back}, {k.quit},
 	}
 }
 
 func newKeyMap() keyMap {
 	return keyMap{
-		newSearch: key.NewBinding(
-			key.WithKeys("n"),
-			key.WithHelp("n", "new search"),
-		),
-		recentLocations: key.NewBinding(
-			key.WithKeys("b"),
-			key.WithHelp("b", "recent locations"),
-		),
-		refresh: key.NewBinding(
-			key.WithKeys("r"),
-			key.WithHelp("r", "refresh"),
-		),
-		quit: key.NewBinding(
-			key.WithKeys("q"),
-			key.WithHelp("q", "quit"),
-		),
+		newSearch:       keybind.Binding(keybind.NewSearch, i18n.T("new search")),
+		recentLocations: keybind.Binding(keybind.Recent, i18n.T("recent locations")),
+		refresh:         keybind.Binding(keybind.Refresh, i18n.T("refresh")),
+		compare:         keybind.Binding(keybind.Compare, i18n.T("compare models")),
+		history:         keybind.Binding(keybind.History, i18n.T("history")),
+		nextTab:         keybind.Binding(keybind.NextTab, i18n.T("next tab")),
+		back:            keybind.Binding(keybind.Back, i18n.T("back")),
+		quit:            keybind.Binding(keybind.Quit, i18n.T("quit")),
 	}
 }
 
@@ -66,6 +75,8 @@ func newKeyMap() keyMap {
 
 type dataMsg struct {
 	forecast openmeteo.ForecastResponse
+	provider provider.Provider
+	alerts   []alert.Alert
 }
 
 type errorMsg struct {
@@ -80,39 +91,32 @@ type NewSearchMsg struct{}
 
 type RecentMsg struct{}
 
+type CompareMsg struct {
+	Location openmeteo.GeocodingResult
+}
+
+type HistoryMsg struct {
+	Location openmeteo.GeocodingResult
+	Forecast openmeteo.ForecastResponse
+}
+
 // ---- cmd ----
 
-func getForecastCmd(lat float64, long float64) tea.Cmd {
+// Fetch the forecast and check the alert rules against it.
+func getForecastCmd(cfg config.Config, location openmeteo.GeocodingResult) tea.Cmd {
 	return func() tea.Msg {
-		params := openmeteo.ForecastParams{
-			Latitude:  lat,
-			Longitude: long,
-			Current: []openmeteo.CurrentWeatherVariables{
-				openmeteo.Temperature2m,
-				openmeteo.ApparentTemperature,
-				openmeteo.RelativeHumidity2m,
-				openmeteo.IsDay,
-				openmeteo.WeatherCode,
-				openmeteo.WindSpeed10m,
-				openmeteo.WindDirection10m,
-				openmeteo.WindGusts10m,
-				openmeteo.Precipitation,
-				openmeteo.SeaLevelPressure,
-			},
-			Daily: []openmeteo.DailyWeatherVariables{
-				openmeteo.Temperature2mMin,
-				openmeteo.Temperature2mMax,
-				openmeteo.UVIndexMax,
-			},
-		}
-		res, err := openmeteo.GetForecast(params)
+		res, source, err := forecast.Fetch(cfg, location)
 		if err != nil {
 			return errorMsg{
 				err: err,
 			}
 		}
+		// Validated at startup
+		rules, _ := alert.FromConfig(cfg)
 		return dataMsg{
 			forecast: res,
+			provider: source,
+			alerts:   alert.Evaluate(rules, location, res, time.Now()),
 		}
 	}
 }
@@ -129,6 +133,18 @@ func requestRecentCmd() tea.Cmd {
 	}
 }
 
+func requestCompareCmd(location openmeteo.GeocodingResult) tea.Cmd {
+	return func() tea.Msg {
+		return CompareMsg{Location: location}
+	}
+}
+
+func requestHistoryCmd(location openmeteo.GeocodingResult, forecast openmeteo.ForecastResponse) tea.Cmd {
+	return func() tea.Msg {
+		return HistoryMsg{Location: location, Forecast: forecast}
+	}
+}
+
 func saveRecentLocationCmd(location openmeteo.GeocodingResult) tea.Cmd {
 	return func() tea.Msg {
 		err := store.AddRecentLocation(location)
@@ -146,51 +162,221 @@ const (
 	viewError
 )
 
+type tab int
+
+const (
+	tabForecast tab = iota
+	tabAirQuality
+	tabMarine
+	tabAstronomy
+)
+
+var tabNames = map[tab]string{
+	tabForecast:   "Forecast",
+	tabAirQuality: "Air quality",
+	tabMarine:     "Marine",
+	tabAstronomy:  "Sun & moon",
+}
+
 type Model struct {
 	view     view
-	errStr   string
+	failure  errorview.Model
 	ellipsis spinner.Model
+	cfg      config.Config
 	location openmeteo.GeocodingResult
 	forecast openmeteo.ForecastResponse
-	keys     keyMap
-	help     help.Model
+	provider provider.Provider
+	normals  *climate.Normals
+	air      airQuality
+	sea      marine
+	// Alert rules that hold in the last forecast
+	alerts  []alert.Alert
+	icons   icons.Style
+	tab     tab
+	now     time.Time
+	clockID int
+	// Background refresh, which keeps the current data on screen
+	fetchedAt  time.Time
+	refreshing bool
+	refreshErr string
+	refreshID  int
+	focused    bool
+	keys       keyMap
+	help       help.Model
+}
+
+// Last id given to a clock or refresh timer. Ids are never reused, so a model
+// drops the ticks of the model it replaced. Only touched from Update.
+var lastTickID int
+
+func nextTickID() int {
+	lastTickID++
+	return lastTickID
+}
+
+// Tabs with content to show, in display order.
+func (m Model) tabs() []tab {
+	tabs := []tab{tabForecast, tabAirQuality}
+	if m.sea.available() {
+		tabs = append(tabs, tabMarine)
+	}
+	return append(tabs, tabAstronomy)
+}
+
+func (m Model) fetchCmd() tea.Cmd {
+	return tea.Batch(
+		getForecastCmd(m.cfg, m.location),
+		getAirQualityCmd(m.location.Latitude, m.location.Longitude),
+		getMarineCmd(m.location.Latitude, m.location.Longitude),
+	)
 }
 
 func (m Model) Init() tea.Cmd {
 	return tea.Batch(
 		saveRecentLocationCmd(m.location),
-		getForecastCmd(m.location.Latitude, m.location.Longitude),
+		m.fetchCmd(),
+		getNormalsCmd(m.location.Latitude, m.location.Longitude, m.cfg.Units.Temperature),
+		tickClockCmd(m.clockID),
+		tickRefreshCmd(m.refreshID, m.refreshInterval()),
 		m.ellipsis.Tick,
 	)
 }
 
+// Restart the clock and refresh timer after coming back from another route,
+// their ticks are not delivered while the model is hidden.
+// Data that got old in the meantime is refreshed right away.
+func (m Model) Resume() (Model, tea.Cmd) {
+	m.clockID = nextTickID()
+	m.now = time.Now()
+	cmds := []tea.Cmd{tickClockCmd(m.clockID)}
+
+	var cmd tea.Cmd
+	m, cmd = m.restartRefreshTimer()
+	cmds = append(cmds, cmd)
+	if m.view == viewReady && m.focused && m.refreshDue() {
+		m, cmd = m.startRefresh()
+		cmds = append(cmds, cmd)
+	}
+	return m, tea.Batch(cmds...)
+}
+
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.view == viewError {
+			if key.Matches(msg, m.failure.Keys.Retry) {
+				m.view = viewLoading
+				return m, tea.Batch(m.fetchCmd(), m.ellipsis.Tick)
+			}
+			if key.Matches(msg, m.failure.Keys.Back) {
+				return m, nav.Back()
+			}
+		}
 		if key.Matches(msg, m.keys.newSearch) {
 			return m, requestNewSearchCmd()
 		}
 		if key.Matches(msg, m.keys.recentLocations) {
 			return m, requestRecentCmd()
 		}
+		if key.Matches(msg, m.keys.back) {
+			return m, nav.Back()
+		}
 		if key.Matches(msg, m.keys.refresh) && m.view == viewReady {
-			m.view = viewLoading
-			return m, tea.Batch(getForecastCmd(m.location.Latitude, m.location.Longitude), m.ellipsis.Tick)
+			return m.startRefresh()
+		}
+		if key.Matches(msg, m.keys.nextTab) && m.view == viewReady {
+			tabs := m.tabs()
+			for i, t := range tabs {
+				if t == m.tab {
+					m.tab = tabs[(i+1)%len(tabs)]
+					break
+				}
+			}
+			return m, nil
+		}
+		if key.Matches(msg, m.keys.compare) && m.view == viewReady {
+			return m, requestCompareCmd(m.location)
+		}
+		if key.Matches(msg, m.keys.history) && m.view == viewReady {
+			return m, requestHistoryCmd(m.location, m.forecast)
 		}
 		if key.Matches(msg, m.keys.quit) {
 			return m, tea.Quit
 		}
 	case dataMsg:
 		m.forecast = msg.forecast
+		m.provider = msg.provider
+		m.alerts = msg.alerts
 		m.view = viewReady
+		m.fetchedAt = time.Now()
+		m.refreshing = false
 		return m, nil
 	case errorMsg:
+		// A failed background refresh keeps the older data
+		if m.refreshing && m.view == viewReady {
+			m.refreshing = false
+			m.refreshErr = msg.err.Error()
+			return m, nil
+		}
 		m.view = viewError
-		m.errStr = msg.err.Error()
+		m.failure = errorview.New(i18n.T("Failed to get weather forecast"), msg.err, errorview.NewKeyMap(i18n.T("back")))
+		return m, nil
+	case refreshMsg:
+		if msg.id != m.refreshID {
+			return m, nil
+		}
+		// Stop ticking while unfocused, focusing again refreshes if due
+		if !m.focused {
+			return m, nil
+		}
+		cmd := tickRefreshCmd(m.refreshID, m.refreshInterval())
+		if m.view != viewReady {
+			return m, cmd
+		}
+		var refreshCmd tea.Cmd
+		m, refreshCmd = m.startRefresh()
+		return m, tea.Batch(cmd, refreshCmd)
+	case tea.BlurMsg:
+		m.focused = false
+		return m, nil
+	case tea.FocusMsg:
+		m.focused = true
+		var cmd tea.Cmd
+		m, cmd = m.restartRefreshTimer()
+		if m.view == viewReady && m.refreshDue() {
+			var refreshCmd tea.Cmd
+			m, refreshCmd = m.startRefresh()
+			cmd = tea.Batch(cmd, refreshCmd)
+		}
+		return m, cmd
+	case airQualityMsg:
+		m.air = airQuality{data: msg.data, err: msg.err, loaded: true}
+		return m, nil
+	case clockMsg:
+		if msg.id != m.clockID {
+			return m, nil
+		}
+		m.now = msg.now
+		return m, tickClockCmd(m.clockID)
+	case normalsMsg:
+		// Normals only annotate the forecast, it is fine to go without them
+		if msg.err == nil {
+			m.normals = &msg.normals
+		}
+		return m, nil
+	case marineMsg:
+		// Inland points either fail or come back empty, both hide the tab
+		if msg.err != nil {
+			msg.data = openmeteo.MarineResponse{}
+		}
+		m.sea = marine{data: msg.data}
+		if m.tab == tabMarine && !m.sea.available() {
+			m.tab = tabForecast
+		}
 		return m, nil
 	}
 
-	if m.view == viewLoading {
+	if m.view == viewLoading || m.refreshing {
 		var cmd tea.Cmd
 		m.ellipsis, cmd = m.ellipsis.Update(msg)
 		return m, cmd
@@ -202,99 +388,204 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 func (m Model) View() string {
 	switch m.view {
 	case viewLoading:
-		return fmt.Sprintf("\nLoading forecast%s\n", m.ellipsis.View())
+		return i18n.T("\nLoading forecast%s\n", m.ellipsis.View())
 	case viewReady:
-		weather := m.forecast
-		s := fmt.Sprintf("\n%s, %s", m.location.Name, m.location.Country)
+		s := "\n" + m.location.Label()
+		if len(m.cfg.Providers) > 1 && m.provider.Name != m.cfg.Providers[0].Name {
+			s += theme.Current().Subtle.Render(i18n.T(" (via %s)", m.provider.Name))
+		}
+		if updated := m.updatedView(); updated != "" {
+			s += "  " + updated
+		}
+		s += m.alertsView()
+		s += "\n" + m.tabsView() + "\n"
+
+		switch m.tab {
+		case tabAirQuality:
+			s += m.air.view()
+		case tabMarine:
+			s += m.sea.view()
+		case tabAstronomy:
+			s += m.astronomyView()
+		default:
+			s += m.forecastView()
+		}
+
+		helpView := m.help.View(m.keys)
+		return s + "\n\n" + helpView
+	case viewError:
+		return m.failure.View()
+	default:
+		return "\nunknown error state (weather)"
+	}
+}
 
-		weatherCode, ok := weather.Current[string(openmeteo.WeatherCode)].(float64)
-		if ok {
-			weatherInterpretation := fmt.Sprintf("\n%s", openmeteo.MapWeatherCode(weatherCode))
-			s += accent.Render(weatherInterpretation)
+func (m Model) tabsView() string {
+	names := make([]string, 0, len(m.tabs()))
+	for _, t := range m.tabs() {
+		if t == m.tab {
+			names = append(names, theme.Current().Accent.Render(i18n.T(tabNames[t])))
+		} else {
+			names = append(names, theme.Current().Subtle.Render(i18n.T(tabNames[t])))
 		}
+	}
+	return strings.Join(names, theme.Current().Subtle.Render(" | "))
+}
+
+// Current value with its unit, colored by a scale.
+func currentScaled(res openmeteo.ForecastResponse, variable openmeteo.CurrentWeatherVariables, s scale.Scale) string {
+	unit, _ := res.CurrentUnits[string(variable)].(string)
+	value, ok := res.Current[string(variable)].(float64)
+	if !ok {
+		return "-"
+	}
+	return s.Render(value, unit, i18n.Sprintf("%.1f %s", value, unit))
+}
+
+func (m Model) forecastView() string {
+	weather := m.forecast
+	s := ""
+
+	isDay := true
+	if v, ok := weather.Current[string(openmeteo.IsDay)].(float64); ok {
+		isDay = v == 1
+	}
+	// Unknown codes get the generic icon
+	weatherCode, ok := weather.Current[string(openmeteo.WeatherCode)].(float64)
+	if !ok {
+		weatherCode = -1
+	}
+	icon := icons.For(weatherCode, isDay, m.icons)
+
+	conditions := ""
+	if ok {
+		conditions += theme.Current().Accent.Render(i18n.T(openmeteo.MapWeatherCode(weatherCode))) + "\n"
+	}
+	conditions += currentScaled(weather, openmeteo.Temperature2m, scale.Temperature)
+	conditions += theme.Current().Subtle.Render(i18n.T(" (feels like %.1f %s)", weather.Current[string(openmeteo.ApparentTemperature)], weather.CurrentUnits[string(openmeteo.ApparentTemperature)]))
+
+	if m.icons == icons.StyleASCII {
+		s += "\n" + lipgloss.JoinHorizontal(lipgloss.Center, theme.Current().Accent.Render(icon), "  ", conditions) + "\n"
+	} else {
+		s += "\n" + icon + "  " + conditions
+	}
 
-		temperature := fmt.Sprintf("\n%.1f %s", weather.Current[string(openmeteo.Temperature2m)], weather.CurrentUnits[string(openmeteo.Temperature2m)])
-		temperature = temperature + subtle.Render(fmt.Sprintf(" (feels like %.1f %s)", weather.Current[string(openmeteo.ApparentTemperature)], weather.CurrentUnits[string(openmeteo.ApparentTemperature)]))
-		s += temperature
-
-		minTempLabel := label.Render("\nMin")
-		var minTempValue string
-		if minArray, ok := weather.Daily[string(openmeteo.Temperature2mMin)].([]any); ok && len(minArray) > 0 {
-			if minTemp, ok := minArray[0].(float64); ok {
-				minTempValue = fmt.Sprintf("%.1f %s", minTemp, weather.DailyUnits[string(openmeteo.Temperature2mMin)])
-			} else {
-				minTempValue = "-"
+	minTempLabel := label().Render("\n" + i18n.T("Min"))
+	var minTempValue string
+	if minArray, ok := weather.Daily[string(openmeteo.Temperature2mMin)].([]any); ok && len(minArray) > 0 {
+		if minTemp, ok := minArray[0].(float64); ok {
+			unit, _ := weather.DailyUnits[string(openmeteo.Temperature2mMin)].(string)
+			minTempValue = scale.Temperature.Render(minTemp, unit, i18n.Sprintf("%.1f %s", minTemp, unit))
+			if normal, ok := m.normalFor(0); ok {
+				minTempValue += anomalyView(normal.MinAnomaly(minTemp), "record low")
 			}
 		} else {
 			minTempValue = "-"
 		}
-		s += minTempLabel + minTempValue
-
-		maxTempLabel := label.Render("\nMax")
-		var maxTempValue string
-		if maxArray, ok := weather.Daily[string(openmeteo.Temperature2mMax)].([]any); ok && len(maxArray) > 0 {
-			if maxTemp, ok := maxArray[0].(float64); ok {
-				maxTempValue = fmt.Sprintf("%.1f %s", maxTemp, weather.DailyUnits[string(openmeteo.Temperature2mMax)])
-			} else {
-				maxTempValue = "-"
+	} else {
+		minTempValue = "-"
+	}
+	s += minTempLabel + minTempValue
+
+	maxTempLabel := label().Render("\n" + i18n.T("Max"))
+	var maxTempValue string
+	if maxArray, ok := weather.Daily[string(openmeteo.Temperature2mMax)].([]any); ok && len(maxArray) > 0 {
+		if maxTemp, ok := maxArray[0].(float64); ok {
+			unit, _ := weather.DailyUnits[string(openmeteo.Temperature2mMax)].(string)
+			maxTempValue = scale.Temperature.Render(maxTemp, unit, i18n.Sprintf("%.1f %s", maxTemp, unit))
+			if normal, ok := m.normalFor(0); ok {
+				maxTempValue += anomalyView(normal.MaxAnomaly(maxTemp), "record high")
 			}
 		} else {
 			maxTempValue = "-"
 		}
-		s += maxTempLabel + maxTempValue
-
-		windLabel := label.Render("\n\nWind")
-		windValue := fmt.Sprintf("%.1f %s @ %.1f %s", weather.Current[string(openmeteo.WindSpeed10m)], weather.CurrentUnits[string(openmeteo.WindSpeed10m)], weather.Current[string(openmeteo.WindDirection10m)], weather.CurrentUnits[string(openmeteo.WindDirection10m)])
-		s += windLabel + windValue
-
-		windGustsLabel := label.Render("\nWind gusts")
-		windGustsValue := fmt.Sprintf("%.1f %s", weather.Current[string(openmeteo.WindGusts10m)], weather.CurrentUnits[string(openmeteo.WindGusts10m)])
-		s += windGustsLabel + windGustsValue
-
-		humidityLabel := label.Render("\nHumidity")
-		humidityValue := fmt.Sprintf("%.1f %s", weather.Current[string(openmeteo.RelativeHumidity2m)], weather.CurrentUnits[string(openmeteo.RelativeHumidity2m)])
-		s += humidityLabel + humidityValue
-
-		precipitationLabel := label.Render("\nPrecipitation")
-		precipitationValue := fmt.Sprintf("%.1f %s", weather.Current[string(openmeteo.Precipitation)], weather.CurrentUnits[string(openmeteo.Precipitation)])
-		s += precipitationLabel + precipitationValue
-
-		pressureLabel := label.Render("\nPressure")
-		pressureValue := fmt.Sprintf("%.1f %s", weather.Current[string(openmeteo.SeaLevelPressure)], weather.CurrentUnits[string(openmeteo.SeaLevelPressure)])
-		s += pressureLabel + pressureValue
-
-		uvLabel := label.Render("\nUV index")
-		var uvValue string
-		if uvArray, ok := weather.Daily[string(openmeteo.UVIndexMax)].([]any); ok && len(uvArray) > 0 {
-			if uvToday, ok := uvArray[0].(float64); ok {
-				uvValue = fmt.Sprintf("%.1f", uvToday)
-			} else {
-				uvValue = "-"
-			}
+	} else {
+		maxTempValue = "-"
+	}
+	s += maxTempLabel + maxTempValue
+
+	derivedConditions, hasDerived := derived.FromForecast(weather)
+
+	windLabel := label().Render("\n\n" + i18n.T("Wind"))
+	windValue := i18n.Sprintf("%.1f %s @ %.1f %s", weather.Current[string(openmeteo.WindSpeed10m)], weather.CurrentUnits[string(openmeteo.WindSpeed10m)], weather.Current[string(openmeteo.WindDirection10m)], weather.CurrentUnits[string(openmeteo.WindDirection10m)])
+	if hasDerived {
+		windValue = i18n.Sprintf("%.1f %s %s", weather.Current[string(openmeteo.WindSpeed10m)], weather.CurrentUnits[string(openmeteo.WindSpeed10m)], i18n.T(derivedConditions.Compass))
+		windValue += theme.Current().Subtle.Render(i18n.T(" (force %d, %s)", derivedConditions.Beaufort.Number, strings.ToLower(i18n.T(derivedConditions.Beaufort.Description))))
+	}
+	s += windLabel + windValue
+
+	windGustsLabel := label().Render("\n" + i18n.T("Wind gusts"))
+	windGustsValue := currentScaled(weather, openmeteo.WindGusts10m, scale.WindGusts)
+	s += windGustsLabel + windGustsValue
+
+	humidityLabel := label().Render("\n" + i18n.T("Humidity"))
+	humidityValue := i18n.Sprintf("%.1f %s", weather.Current[string(openmeteo.RelativeHumidity2m)], weather.CurrentUnits[string(openmeteo.RelativeHumidity2m)])
+	s += humidityLabel + humidityValue
+
+	if hasDerived {
+		temperatureUnit := weather.CurrentUnits[string(openmeteo.Temperature2m)]
+		s += label().Render("\n"+i18n.T("Dew point")) + i18n.Sprintf("%.1f %s", derivedConditions.DewPoint, temperatureUnit)
+		if derivedConditions.HasHeatIndex {
+			s += label().Render("\n"+i18n.T("Heat index")) + i18n.Sprintf("%.1f %s", derivedConditions.HeatIndex, temperatureUnit)
+		}
+		if derivedConditions.HasHumidex {
+			s += label().Render("\n"+i18n.T("Humidex")) + i18n.Sprintf("%.1f %s", derivedConditions.Humidex, temperatureUnit)
+		}
+		if derivedConditions.HasWindChill {
+			s += label().Render("\n"+i18n.T("Wind chill")) + i18n.Sprintf("%.1f %s", derivedConditions.WindChill, temperatureUnit)
+		}
+		s += label().Render("\n"+i18n.T("Comfort")) + i18n.T(derivedConditions.Comfort)
+	}
+
+	precipitationLabel := label().Render("\n" + i18n.T("Precipitation"))
+	precipitationValue := currentScaled(weather, openmeteo.Precipitation, scale.PrecipitationRate)
+	s += precipitationLabel + precipitationValue
+
+	pressureLabel := label().Render("\n" + i18n.T("Pressure"))
+	pressureValue := i18n.Sprintf("%.1f %s", weather.Current[string(openmeteo.SeaLevelPressure)], weather.CurrentUnits[string(openmeteo.SeaLevelPressure)])
+	s += pressureLabel + pressureValue
+
+	uvLabel := label().Render("\n" + i18n.T("UV index"))
+	var uvValue string
+	if uvArray, ok := weather.Daily[string(openmeteo.UVIndexMax)].([]any); ok && len(uvArray) > 0 {
+		if uvToday, ok := uvArray[0].(float64); ok {
+			uvValue = scale.UV.Render(uvToday, "", i18n.Sprintf("%.1f", uvToday))
 		} else {
 			uvValue = "-"
 		}
-		s += uvLabel + uvValue
-
-		helpView := m.help.View(m.keys)
-		return s + "\n\n" + helpView
-	case viewError:
-		return "\nFailed to get weather forecast:" + m.errStr
-	default:
-		return "\nunknown error state (weather)"
+	} else {
+		uvValue = "-"
 	}
+	s += uvLabel + uvValue
+
+	s += "\n\n" + m.dailyView()
+
+	return s
 }
 
-func New(location openmeteo.GeocodingResult) Model {
+func New(location openmeteo.GeocodingResult, cfg config.Config) Model {
 	ellipsis := spinner.New()
 	ellipsis.Spinner = spinner.Ellipsis
-	ellipsis.Style = accent
+	ellipsis.Style = theme.Current().Accent
+
+	// Validated at startup, fall back to the default just in case
+	iconStyle, err := icons.ParseStyle(cfg.Icons)
+	if err != nil {
+		iconStyle = icons.StyleASCII
+	}
 
 	return Model{
-		view:     viewLoading,
-		location: location,
-		ellipsis: ellipsis,
-		keys:     newKeyMap(),
-		help:     help.New(),
+		icons:     iconStyle,
+		view:      viewLoading,
+		now:       time.Now(),
+		clockID:   nextTickID(),
+		refreshID: nextTickID(),
+		cfg:       cfg,
+		location:  location,
+		ellipsis:  ellipsis,
+		// Terminals without focus reporting never blur, so start focused
+		focused: true,
+		keys:    newKeyMap(),
+		help:    theme.Help(),
 	}
 }